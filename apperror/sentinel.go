@@ -77,3 +77,243 @@ type ErrEmailNotFound struct{}
 func (err ErrEmailNotFound) Error() string {
 	return "Email not registered yet"
 }
+
+type ErrBookNotDeleted struct{}
+
+func (err ErrBookNotDeleted) Error() string {
+	return "Book has not been deleted"
+}
+
+type ErrInvalidId struct{}
+
+func (err ErrInvalidId) Error() string {
+	return "Invalid id"
+}
+
+type ErrAuthorHasBooks struct{}
+
+func (err ErrAuthorHasBooks) Error() string {
+	return "Author still has books in the catalogue"
+}
+
+type ErrInvalidSort struct{}
+
+func (err ErrInvalidSort) Error() string {
+	return "Unsupported sort field"
+}
+
+type ErrInvalidCursor struct{}
+
+func (err ErrInvalidCursor) Error() string {
+	return "Invalid cursor"
+}
+
+type ErrMixedPagination struct{}
+
+func (err ErrMixedPagination) Error() string {
+	return "Cursor and page pagination cannot be combined"
+}
+
+type ErrInvalidQuantityRange struct{}
+
+func (err ErrInvalidQuantityRange) Error() string {
+	return "Should not be less than quantity_min"
+}
+
+type ErrInvalidDateRange struct{}
+
+func (err ErrInvalidDateRange) Error() string {
+	return "Should be later than created_after"
+}
+
+type ErrInvalidBorrowDateRange struct{}
+
+func (err ErrInvalidBorrowDateRange) Error() string {
+	return "Should be later than borrowed_after"
+}
+
+type ErrDuplicateEmail struct{}
+
+func (err ErrDuplicateEmail) Error() string {
+	return "Email already registered"
+}
+
+type ErrForbidden struct{}
+
+func (err ErrForbidden) Error() string {
+	return "Insufficient role for this action"
+}
+
+type ErrTokenRevoked struct{}
+
+func (err ErrTokenRevoked) Error() string {
+	return "Token has been revoked"
+}
+
+type ErrRefreshTokenReused struct{}
+
+func (err ErrRefreshTokenReused) Error() string {
+	return "Refresh token has already been used, please login again"
+}
+
+type ErrRenewalLimitReached struct{}
+
+func (err ErrRenewalLimitReached) Error() string {
+	return "Loan has reached the maximum number of renewals"
+}
+
+type ErrLoanNotRenewable struct{}
+
+func (err ErrLoanNotRenewable) Error() string {
+	return "Only a borrowed loan can be renewed"
+}
+
+type ErrBookHasHolds struct{}
+
+func (err ErrBookHasHolds) Error() string {
+	return "Book has pending holds"
+}
+
+type ErrStockAvailable struct{}
+
+func (err ErrStockAvailable) Error() string {
+	return "Book is in stock and can be borrowed directly"
+}
+
+type ErrDuplicateHold struct{}
+
+func (err ErrDuplicateHold) Error() string {
+	return "Book is already on hold for this user"
+}
+
+type ErrHoldNotFound struct{}
+
+func (err ErrHoldNotFound) Error() string {
+	return "Hold not found"
+}
+
+type ErrHoldNotActive struct{}
+
+func (err ErrHoldNotActive) Error() string {
+	return "Hold is no longer active"
+}
+
+type ErrLoanLimitReached struct{}
+
+func (err ErrLoanLimitReached) Error() string {
+	return "Maximum number of active loans reached"
+}
+
+type ErrHasOverdueLoans struct{}
+
+func (err ErrHasOverdueLoans) Error() string {
+	return "Overdue books must be returned before borrowing"
+}
+
+type ErrDuplicateLoan struct{}
+
+func (err ErrDuplicateLoan) Error() string {
+	return "Book is already borrowed by this user"
+}
+
+type ErrUnpaidFines struct{}
+
+func (err ErrUnpaidFines) Error() string {
+	return "Outstanding fines must be paid before borrowing"
+}
+
+type ErrAmountExceedsBalance struct{}
+
+func (err ErrAmountExceedsBalance) Error() string {
+	return "Should not exceed the outstanding balance"
+}
+
+type ErrUserNotFound struct{}
+
+func (err ErrUserNotFound) Error() string {
+	return "User not found"
+}
+
+type ErrCopyNotFound struct{}
+
+func (err ErrCopyNotFound) Error() string {
+	return "Copy not found"
+}
+
+type ErrCopyUnavailable struct{}
+
+func (err ErrCopyUnavailable) Error() string {
+	return "Copy is not available for borrowing"
+}
+
+type ErrInvalidCopyTransition struct{}
+
+func (err ErrInvalidCopyTransition) Error() string {
+	return "Status change not allowed for a borrowed or reserved copy"
+}
+
+type ErrCopyHasUnresolvedLoan struct{}
+
+func (err ErrCopyHasUnresolvedLoan) Error() string {
+	return "Copy is still on a lost or claimed returned loan, resolve the loan first"
+}
+
+type ErrDuplicateBarcode struct{}
+
+func (err ErrDuplicateBarcode) Error() string {
+	return "Already existed"
+}
+
+type ErrInvalidBorrowTransition struct{}
+
+func (err ErrInvalidBorrowTransition) Error() string {
+	return "Status change not allowed for this borrowing record"
+}
+
+type ErrReplacementChargeNotAllowed struct{}
+
+func (err ErrReplacementChargeNotAllowed) Error() string {
+	return "Only lost or damaged records can be charged"
+}
+
+type ErrBorrowerRequired struct{}
+
+func (err ErrBorrowerRequired) Error() string {
+	return "Required"
+}
+
+type ErrBorrowerNotMember struct{}
+
+func (err ErrBorrowerNotMember) Error() string {
+	return "Should be a member"
+}
+
+type ErrInvalidIdempotencyKey struct{}
+
+func (err ErrInvalidIdempotencyKey) Error() string {
+	return "Idempotency key should be at most 255 characters"
+}
+
+type ErrIdempotencyKeyReused struct{}
+
+func (err ErrIdempotencyKeyReused) Error() string {
+	return "Idempotency key already used for a different request"
+}
+
+type ErrIdempotencyKeyInProgress struct{}
+
+func (err ErrIdempotencyKeyInProgress) Error() string {
+	return "A request with this idempotency key is still in progress"
+}
+
+type ErrNotReady struct{}
+
+func (err ErrNotReady) Error() string {
+	return "Not ready"
+}
+
+type ErrShuttingDown struct{}
+
+func (err ErrShuttingDown) Error() string {
+	return "Shutting down"
+}
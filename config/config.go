@@ -0,0 +1,490 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	DB          DBConfig          `yaml:"db"`
+	JWT         JWTConfig         `yaml:"jwt"`
+	Loan        LoanConfig        `yaml:"loan"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Reminder    ReminderConfig    `yaml:"reminder"`
+	Notifier    NotifierConfig    `yaml:"notifier"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	Cleanup     CleanupConfig     `yaml:"cleanup"`
+}
+
+type ServerConfig struct {
+	Port            string        `yaml:"port"`
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	DrainDelay      time.Duration `yaml:"drain_delay"`
+	AutoMigrate     bool          `yaml:"auto_migrate"`
+}
+
+type DBConfig struct {
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	Name            string        `yaml:"name"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	SSLMode         string        `yaml:"sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+type JWTConfig struct {
+	Secret          string        `yaml:"secret"`
+	Issuer          string        `yaml:"issuer"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl"`
+}
+
+type LoanConfig struct {
+	LoanDays          int   `yaml:"loan_days"`
+	MaxRenewals       int   `yaml:"max_renewals"`
+	HoldPickupDays    int   `yaml:"hold_pickup_days"`
+	MaxLoansMember    int   `yaml:"max_loans_member"`
+	MaxLoansLibrarian int   `yaml:"max_loans_librarian"`
+	DailyFine         int64 `yaml:"daily_fine"`
+	FineThreshold     int64 `yaml:"fine_threshold"`
+}
+
+type IdempotencyConfig struct {
+	KeyTTL time.Duration `yaml:"key_ttl"`
+	Lease  time.Duration `yaml:"lease"`
+}
+
+type ReminderConfig struct {
+	Interval    time.Duration `yaml:"interval"`
+	DueSoonDays int           `yaml:"due_soon_days"`
+	BatchSize   int           `yaml:"batch_size"`
+	MaxAttempts int           `yaml:"max_attempts"`
+	RetryDelay  time.Duration `yaml:"retry_delay"`
+}
+
+type NotifierConfig struct {
+	Kind    string     `yaml:"kind"`
+	LogFile string     `yaml:"log_file"`
+	SMTP    SMTPConfig `yaml:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `yaml:"exporter"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	OTLPInsecure bool    `yaml:"otlp_insecure"`
+	SampleRatio  float64 `yaml:"sample_ratio"`
+	ServiceName  string  `yaml:"service_name"`
+}
+
+type CleanupConfig struct {
+	Interval time.Duration `yaml:"interval"`
+}
+
+var Default = Config{
+	Server: ServerConfig{
+		Port:            "8080",
+		ReadTimeout:     5 * time.Second,
+		WriteTimeout:    5 * time.Second,
+		ShutdownTimeout: 5 * time.Second,
+		DrainDelay:      5 * time.Second,
+	},
+	DB: DBConfig{
+		Port:            5432,
+		SSLMode:         "disable",
+		MaxOpenConns:    25,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 30 * time.Minute,
+		ConnMaxIdleTime: 5 * time.Minute,
+	},
+	JWT: JWTConfig{
+		Issuer:          "archive_lib",
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 7 * 24 * time.Hour,
+	},
+	Loan: LoanConfig{
+		LoanDays:          14,
+		MaxRenewals:       2,
+		HoldPickupDays:    3,
+		MaxLoansMember:    5,
+		MaxLoansLibrarian: 10,
+		DailyFine:         50,
+		FineThreshold:     0,
+	},
+	Idempotency: IdempotencyConfig{
+		KeyTTL: 24 * time.Hour,
+		Lease:  time.Minute,
+	},
+	Reminder: ReminderConfig{
+		Interval:    time.Hour,
+		DueSoonDays: 2,
+		BatchSize:   100,
+		MaxAttempts: 5,
+		RetryDelay:  15 * time.Minute,
+	},
+	Notifier: NotifierConfig{
+		Kind: "log",
+		SMTP: SMTPConfig{
+			Port: "587",
+		},
+	},
+	Tracing: TracingConfig{
+		Exporter:    "none",
+		SampleRatio: 1,
+		ServiceName: "archive_lib",
+	},
+	Cleanup: CleanupConfig{
+		Interval: time.Hour,
+	},
+}
+
+var traceExporters = []string{"none", "stdout", "otlp"}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Error lists every problem found in the configuration.
+type Error struct {
+	Problems []string
+}
+
+func (err Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(err.Problems, "\n  - ")
+}
+
+// Load builds the configuration from the defaults, then the YAML file named by CONFIG_FILE,
+// then the environment, each overriding the previous one. Variables from .env fill in the
+// environment without replacing variables already set, and the file is optional.
+// Every problem is reported at once in an Error.
+func Load() (*Config, error) {
+	cfg, l := loadFiles()
+
+	l.readServer(&cfg.Server)
+	l.readDB(&cfg.DB)
+	l.readJWT(&cfg.JWT)
+	l.readLoan(&cfg.Loan)
+	l.readIdempotency(&cfg.Idempotency)
+	l.readReminder(&cfg.Reminder)
+	l.readNotifier(&cfg.Notifier)
+	l.readTracing(&cfg.Tracing)
+	l.readCleanup(&cfg.Cleanup)
+
+	l.validate(cfg)
+
+	if len(l.problems) > 0 {
+		return nil, Error{Problems: l.problems}
+	}
+
+	return cfg, nil
+}
+
+// LoadDB loads the database settings the same way as Load and only validates them, for
+// commands such as migrate that need nothing else.
+func LoadDB() (*DBConfig, error) {
+	cfg, l := loadFiles()
+
+	l.readDB(&cfg.DB)
+	l.validateDB(&cfg.DB)
+
+	if len(l.problems) > 0 {
+		return nil, Error{Problems: l.problems}
+	}
+
+	return &cfg.DB, nil
+}
+
+// loadFiles starts from the defaults, loads .env into the environment, and applies the YAML file.
+func loadFiles() (*Config, *loader) {
+	cfg := Default
+	l := &loader{}
+
+	err := godotenv.Load()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		l.problem(".env: %v", err)
+	}
+
+	path := os.Getenv("CONFIG_FILE")
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			l.problem("CONFIG_FILE: %v", err)
+		} else {
+			err = yaml.Unmarshal(content, &cfg)
+			if err != nil {
+				l.problem("%s: %v", path, err)
+			}
+		}
+	}
+
+	return &cfg, l
+}
+
+// URL is the connection string of the database, with the credentials escaped.
+func (c DBConfig) URL() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+
+	return u.String()
+}
+
+type loader struct {
+	problems []string
+}
+
+func (l *loader) problem(format string, args ...any) {
+	l.problems = append(l.problems, fmt.Sprintf(format, args...))
+}
+
+func (l *loader) check(ok bool, format string, args ...any) {
+	if !ok {
+		l.problem(format, args...)
+	}
+}
+
+func (l *loader) readString(dst *string, key string) {
+	raw := os.Getenv(key)
+	if raw != "" {
+		*dst = raw
+	}
+}
+
+func (l *loader) readInt(dst *int, key string) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		l.problem("%s should be an integer, got %q", key, raw)
+		return
+	}
+	*dst = value
+}
+
+func (l *loader) readInt64(dst *int64, key string) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		l.problem("%s should be an integer, got %q", key, raw)
+		return
+	}
+	*dst = value
+}
+
+func (l *loader) readFloat(dst *float64, key string) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		l.problem("%s should be a number, got %q", key, raw)
+		return
+	}
+	*dst = value
+}
+
+func (l *loader) readBool(dst *bool, key string) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		l.problem("%s should be true or false, got %q", key, raw)
+		return
+	}
+	*dst = value
+}
+
+func (l *loader) readDuration(dst *time.Duration, key string) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		l.problem("%s should be a duration such as 5s or 1h, got %q", key, raw)
+		return
+	}
+	*dst = value
+}
+
+func (l *loader) readServer(cfg *ServerConfig) {
+	l.readString(&cfg.Port, "SERVER_PORT")
+	l.readDuration(&cfg.ReadTimeout, "SERVER_READ_TIMEOUT")
+	l.readDuration(&cfg.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	l.readDuration(&cfg.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT")
+	l.readDuration(&cfg.DrainDelay, "SERVER_DRAIN_DELAY")
+	l.readBool(&cfg.AutoMigrate, "AUTO_MIGRATE")
+}
+
+func (l *loader) readDB(cfg *DBConfig) {
+	l.readString(&cfg.Host, "DB_HOST")
+	l.readInt(&cfg.Port, "DB_PORT")
+	l.readString(&cfg.Name, "DB_NAME")
+	l.readString(&cfg.User, "DB_USER_NAME")
+	l.readString(&cfg.Password, "DB_USER_PASSWORD")
+	l.readString(&cfg.SSLMode, "DB_SSLMODE")
+	l.readInt(&cfg.MaxOpenConns, "DB_MAX_OPEN_CONNS")
+	l.readInt(&cfg.MaxIdleConns, "DB_MAX_IDLE_CONNS")
+	l.readDuration(&cfg.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME")
+	l.readDuration(&cfg.ConnMaxIdleTime, "DB_CONN_MAX_IDLE_TIME")
+}
+
+func (l *loader) readJWT(cfg *JWTConfig) {
+	l.readString(&cfg.Secret, "JWT_SECRET")
+	l.readString(&cfg.Issuer, "JWT_ISSUER")
+	l.readDuration(&cfg.AccessTokenTTL, "JWT_ACCESS_TOKEN_TTL")
+	l.readDuration(&cfg.RefreshTokenTTL, "JWT_REFRESH_TOKEN_TTL")
+}
+
+func (l *loader) readLoan(cfg *LoanConfig) {
+	l.readInt(&cfg.LoanDays, "LOAN_PERIOD_DAYS")
+	l.readInt(&cfg.MaxRenewals, "LOAN_MAX_RENEWALS")
+	l.readInt(&cfg.HoldPickupDays, "HOLD_PICKUP_DAYS")
+	l.readInt(&cfg.MaxLoansMember, "MAX_LOANS_MEMBER")
+	l.readInt(&cfg.MaxLoansLibrarian, "MAX_LOANS_LIBRARIAN")
+	l.readInt64(&cfg.DailyFine, "FINE_DAILY_AMOUNT")
+	l.readInt64(&cfg.FineThreshold, "FINE_DEBT_THRESHOLD")
+}
+
+func (l *loader) readIdempotency(cfg *IdempotencyConfig) {
+	l.readDuration(&cfg.KeyTTL, "IDEMPOTENCY_KEY_TTL")
+	l.readDuration(&cfg.Lease, "IDEMPOTENCY_LEASE")
+}
+
+func (l *loader) readReminder(cfg *ReminderConfig) {
+	l.readDuration(&cfg.Interval, "REMINDER_INTERVAL")
+	l.readInt(&cfg.DueSoonDays, "REMINDER_DUE_SOON_DAYS")
+	l.readInt(&cfg.BatchSize, "REMINDER_BATCH_SIZE")
+	l.readInt(&cfg.MaxAttempts, "REMINDER_MAX_ATTEMPTS")
+	l.readDuration(&cfg.RetryDelay, "REMINDER_RETRY_DELAY")
+}
+
+func (l *loader) readNotifier(cfg *NotifierConfig) {
+	l.readString(&cfg.Kind, "NOTIFIER")
+	l.readString(&cfg.LogFile, "NOTIFICATION_LOG_FILE")
+	l.readString(&cfg.SMTP.Host, "SMTP_HOST")
+	l.readString(&cfg.SMTP.Port, "SMTP_PORT")
+	l.readString(&cfg.SMTP.Username, "SMTP_USERNAME")
+	l.readString(&cfg.SMTP.Password, "SMTP_PASSWORD")
+	l.readString(&cfg.SMTP.From, "SMTP_FROM")
+}
+
+func (l *loader) readTracing(cfg *TracingConfig) {
+	l.readString(&cfg.Exporter, "TRACING_EXPORTER")
+	l.readString(&cfg.OTLPEndpoint, "TRACING_OTLP_ENDPOINT")
+	l.readBool(&cfg.OTLPInsecure, "TRACING_OTLP_INSECURE")
+	l.readFloat(&cfg.SampleRatio, "TRACING_SAMPLE_RATIO")
+	l.readString(&cfg.ServiceName, "TRACING_SERVICE_NAME")
+}
+
+func (l *loader) readCleanup(cfg *CleanupConfig) {
+	l.readDuration(&cfg.Interval, "CLEANUP_INTERVAL")
+}
+
+func (l *loader) validate(cfg *Config) {
+	l.check(cfg.Server.Port != "", "SERVER_PORT is required")
+	l.check(cfg.Server.ReadTimeout > 0, "SERVER_READ_TIMEOUT should be positive")
+	l.check(cfg.Server.WriteTimeout > 0, "SERVER_WRITE_TIMEOUT should be positive")
+	l.check(cfg.Server.ShutdownTimeout > 0, "SERVER_SHUTDOWN_TIMEOUT should be positive")
+	l.check(cfg.Server.DrainDelay >= 0, "SERVER_DRAIN_DELAY should not be negative")
+
+	l.validateDB(&cfg.DB)
+
+	l.check(cfg.JWT.Secret != "", "JWT_SECRET is required")
+	l.check(cfg.JWT.Issuer != "", "JWT_ISSUER is required")
+	l.check(cfg.JWT.AccessTokenTTL > 0, "JWT_ACCESS_TOKEN_TTL should be positive")
+	l.check(cfg.JWT.RefreshTokenTTL > cfg.JWT.AccessTokenTTL, "JWT_REFRESH_TOKEN_TTL should be longer than JWT_ACCESS_TOKEN_TTL")
+
+	l.check(cfg.Loan.LoanDays >= 1, "LOAN_PERIOD_DAYS should be at least 1")
+	l.check(cfg.Loan.MaxRenewals >= 0, "LOAN_MAX_RENEWALS should not be negative")
+	l.check(cfg.Loan.HoldPickupDays >= 1, "HOLD_PICKUP_DAYS should be at least 1")
+	l.check(cfg.Loan.MaxLoansMember >= 1, "MAX_LOANS_MEMBER should be at least 1")
+	l.check(cfg.Loan.MaxLoansLibrarian >= 1, "MAX_LOANS_LIBRARIAN should be at least 1")
+	l.check(cfg.Loan.DailyFine >= 0, "FINE_DAILY_AMOUNT should not be negative")
+	l.check(cfg.Loan.FineThreshold >= 0, "FINE_DEBT_THRESHOLD should not be negative")
+
+	l.check(cfg.Idempotency.KeyTTL > 0, "IDEMPOTENCY_KEY_TTL should be positive")
+	l.check(cfg.Idempotency.Lease > 0, "IDEMPOTENCY_LEASE should be positive")
+
+	l.check(cfg.Reminder.Interval > 0, "REMINDER_INTERVAL should be positive")
+	l.check(cfg.Reminder.DueSoonDays >= 1, "REMINDER_DUE_SOON_DAYS should be at least 1")
+	l.check(cfg.Reminder.BatchSize >= 1, "REMINDER_BATCH_SIZE should be at least 1")
+	l.check(cfg.Reminder.MaxAttempts >= 1, "REMINDER_MAX_ATTEMPTS should be at least 1")
+	l.check(cfg.Reminder.RetryDelay > 0, "REMINDER_RETRY_DELAY should be positive")
+
+	switch cfg.Notifier.Kind {
+	case "log":
+	case "smtp":
+		l.check(cfg.Notifier.SMTP.Host != "", "SMTP_HOST is required when NOTIFIER is smtp")
+		l.check(cfg.Notifier.SMTP.Port != "", "SMTP_PORT is required when NOTIFIER is smtp")
+		l.check(cfg.Notifier.SMTP.From != "", "SMTP_FROM is required when NOTIFIER is smtp")
+	default:
+		l.problem("NOTIFIER should be one of: log smtp")
+	}
+
+	l.check(contains(traceExporters, cfg.Tracing.Exporter), "TRACING_EXPORTER should be one of: %s", strings.Join(traceExporters, " "))
+	l.check(cfg.Tracing.SampleRatio >= 0 && cfg.Tracing.SampleRatio <= 1, "TRACING_SAMPLE_RATIO should be between 0 and 1")
+	l.check(cfg.Tracing.ServiceName != "", "TRACING_SERVICE_NAME is required")
+
+	l.check(cfg.Cleanup.Interval > 0, "CLEANUP_INTERVAL should be positive")
+}
+
+func (l *loader) validateDB(cfg *DBConfig) {
+	l.check(cfg.Host != "", "DB_HOST is required")
+	l.check(cfg.Port > 0 && cfg.Port <= 65535, "DB_PORT should be between 1 and 65535")
+	l.check(cfg.Name != "", "DB_NAME is required")
+	l.check(cfg.User != "", "DB_USER_NAME is required")
+	l.check(contains(sslModes, cfg.SSLMode), "DB_SSLMODE should be one of: %s", strings.Join(sslModes, " "))
+	l.check(cfg.MaxOpenConns >= 1, "DB_MAX_OPEN_CONNS should be at least 1")
+	l.check(cfg.MaxIdleConns >= 0 && cfg.MaxIdleConns <= cfg.MaxOpenConns, "DB_MAX_IDLE_CONNS should be between 0 and DB_MAX_OPEN_CONNS")
+	l.check(cfg.ConnMaxLifetime >= 0, "DB_CONN_MAX_LIFETIME should not be negative")
+	l.check(cfg.ConnMaxIdleTime >= 0, "DB_CONN_MAX_IDLE_TIME should not be negative")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package config_test
+
+import (
+	"archive_lib/config"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var configKeys = []string{
+	"CONFIG_FILE",
+	"SERVER_PORT", "SERVER_READ_TIMEOUT", "SERVER_WRITE_TIMEOUT", "SERVER_SHUTDOWN_TIMEOUT", "SERVER_DRAIN_DELAY", "AUTO_MIGRATE",
+	"DB_HOST", "DB_PORT", "DB_NAME", "DB_USER_NAME", "DB_USER_PASSWORD", "DB_SSLMODE",
+	"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_CONN_MAX_IDLE_TIME",
+	"JWT_SECRET", "JWT_ISSUER", "JWT_ACCESS_TOKEN_TTL", "JWT_REFRESH_TOKEN_TTL",
+	"LOAN_PERIOD_DAYS", "LOAN_MAX_RENEWALS", "HOLD_PICKUP_DAYS", "MAX_LOANS_MEMBER", "MAX_LOANS_LIBRARIAN",
+	"FINE_DAILY_AMOUNT", "FINE_DEBT_THRESHOLD",
+	"IDEMPOTENCY_KEY_TTL", "IDEMPOTENCY_LEASE",
+	"REMINDER_INTERVAL", "REMINDER_DUE_SOON_DAYS", "REMINDER_BATCH_SIZE", "REMINDER_MAX_ATTEMPTS", "REMINDER_RETRY_DELAY",
+	"NOTIFIER", "NOTIFICATION_LOG_FILE", "SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD", "SMTP_FROM",
+	"TRACING_EXPORTER", "TRACING_OTLP_ENDPOINT", "TRACING_OTLP_INSECURE", "TRACING_SAMPLE_RATIO", "TRACING_SERVICE_NAME",
+	"CLEANUP_INTERVAL",
+}
+
+var requiredEnv = map[string]string{
+	"DB_HOST":      "localhost",
+	"DB_NAME":      "archive_lib_db",
+	"DB_USER_NAME": "username",
+	"JWT_SECRET":   "secret",
+}
+
+// isolate runs the test in an empty directory, so no .env is found unless the test writes one,
+// and unsets every configuration variable; the previous values come back when the test ends,
+// including the ones .env loaded into the environment.
+func isolate(t *testing.T, env map[string]string) string {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	for _, key := range configKeys {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+
+	return dir
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should load the defaults when only the required variables are set and there is no .env", func(t *testing.T) {
+		isolate(t, requiredEnv)
+
+		cfg, err := config.Load()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "8080", cfg.Server.Port)
+		assert.Equal(t, 5432, cfg.DB.Port)
+		assert.Equal(t, "secret", cfg.JWT.Secret)
+		assert.Equal(t, 24*time.Hour, cfg.Idempotency.KeyTTL)
+	})
+
+	t.Run("should report every problem at once", func(t *testing.T) {
+		isolate(t, map[string]string{
+			"DB_PORT":             "abc",
+			"SERVER_READ_TIMEOUT": "soon",
+			"NOTIFIER":            "smtp",
+		})
+
+		_, err := config.Load()
+
+		var cfgErr config.Error
+		assert.ErrorAs(t, err, &cfgErr)
+		assert.ElementsMatch(t, []string{
+			`DB_PORT should be an integer, got "abc"`,
+			`SERVER_READ_TIMEOUT should be a duration such as 5s or 1h, got "soon"`,
+			"DB_HOST is required",
+			"DB_NAME is required",
+			"DB_USER_NAME is required",
+			"JWT_SECRET is required",
+			"SMTP_HOST is required when NOTIFIER is smtp",
+			"SMTP_FROM is required when NOTIFIER is smtp",
+		}, cfgErr.Problems)
+	})
+
+	t.Run("should read every interval as a duration", func(t *testing.T) {
+		isolate(t, requiredEnv)
+		t.Setenv("IDEMPOTENCY_KEY_TTL", "90m")
+		t.Setenv("REMINDER_INTERVAL", "30s")
+		t.Setenv("CLEANUP_INTERVAL", "2h")
+
+		cfg, err := config.Load()
+
+		assert.Nil(t, err)
+		assert.Equal(t, 90*time.Minute, cfg.Idempotency.KeyTTL)
+		assert.Equal(t, 30*time.Second, cfg.Reminder.Interval)
+		assert.Equal(t, 2*time.Hour, cfg.Cleanup.Interval)
+	})
+
+	t.Run("should report an interval given without a unit", func(t *testing.T) {
+		isolate(t, requiredEnv)
+		t.Setenv("IDEMPOTENCY_KEY_TTL", "24")
+
+		_, err := config.Load()
+
+		var cfgErr config.Error
+		assert.ErrorAs(t, err, &cfgErr)
+		assert.Equal(t, []string{`IDEMPOTENCY_KEY_TTL should be a duration such as 5s or 1h, got "24"`}, cfgErr.Problems)
+	})
+
+	t.Run("should read the variables from .env", func(t *testing.T) {
+		dir := isolate(t, nil)
+		writeFile(t, filepath.Join(dir, ".env"), "DB_HOST=\"db.local\"\nDB_NAME=\"lib\"\nDB_USER_NAME=\"app\"\nJWT_SECRET=\"from-dotenv\"\n")
+
+		cfg, err := config.Load()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "db.local", cfg.DB.Host)
+		assert.Equal(t, "from-dotenv", cfg.JWT.Secret)
+	})
+
+	t.Run("should not let .env override variables already set", func(t *testing.T) {
+		dir := isolate(t, map[string]string{"JWT_SECRET": "from-env"})
+		writeFile(t, filepath.Join(dir, ".env"), "DB_HOST=\"db.local\"\nDB_NAME=\"lib\"\nDB_USER_NAME=\"app\"\nJWT_SECRET=\"from-dotenv\"\n")
+
+		cfg, err := config.Load()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "from-env", cfg.JWT.Secret)
+		assert.Equal(t, "db.local", cfg.DB.Host)
+	})
+
+	t.Run("should let the environment override CONFIG_FILE, which overrides the defaults", func(t *testing.T) {
+		dir := isolate(t, requiredEnv)
+		path := filepath.Join(dir, "config.yaml")
+		writeFile(t, path, "server:\n  port: \"9000\"\ndb:\n  host: yaml-host\n  max_open_conns: 40\n")
+		t.Setenv("CONFIG_FILE", path)
+		t.Setenv("DB_MAX_OPEN_CONNS", "50")
+		t.Setenv("DB_HOST", "")
+
+		cfg, err := config.Load()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "9000", cfg.Server.Port)
+		assert.Equal(t, "yaml-host", cfg.DB.Host)
+		assert.Equal(t, 50, cfg.DB.MaxOpenConns)
+		assert.Equal(t, 5, cfg.DB.MaxIdleConns)
+	})
+
+	t.Run("should report a CONFIG_FILE that cannot be read", func(t *testing.T) {
+		dir := isolate(t, requiredEnv)
+		t.Setenv("CONFIG_FILE", filepath.Join(dir, "missing.yaml"))
+
+		_, err := config.Load()
+
+		var cfgErr config.Error
+		assert.ErrorAs(t, err, &cfgErr)
+		assert.Len(t, cfgErr.Problems, 1)
+		assert.Contains(t, cfgErr.Problems[0], "CONFIG_FILE:")
+	})
+}
+
+func TestLoadDB(t *testing.T) {
+	t.Run("should not require the settings other than the database", func(t *testing.T) {
+		isolate(t, map[string]string{
+			"DB_HOST":      "localhost",
+			"DB_NAME":      "archive_lib_db",
+			"DB_USER_NAME": "username",
+			"NOTIFIER":     "smtp",
+		})
+
+		dbConfig, err := config.LoadDB()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "localhost", dbConfig.Host)
+		assert.Equal(t, 5432, dbConfig.Port)
+	})
+
+	t.Run("should report every database problem", func(t *testing.T) {
+		isolate(t, map[string]string{"DB_SSLMODE": "always"})
+
+		_, err := config.LoadDB()
+
+		var cfgErr config.Error
+		assert.ErrorAs(t, err, &cfgErr)
+		assert.ElementsMatch(t, []string{
+			"DB_HOST is required",
+			"DB_NAME is required",
+			"DB_USER_NAME is required",
+			"DB_SSLMODE should be one of: disable allow prefer require verify-ca verify-full",
+		}, cfgErr.Problems)
+	})
+}
+
+func TestDBConfigURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		password string
+	}{
+		{name: "plain password", user: "app", password: "secret"},
+		{name: "password with @", user: "app", password: "p@ss"},
+		{name: "password with /", user: "app", password: "pa/ss"},
+		{name: "password with :", user: "app", password: "pa:ss"},
+		{name: "password with every reserved character", user: "app:user", password: "p@s/s:w?o#r%d"},
+	}
+
+	for _, tt := range tests {
+		t.Run("should escape the "+tt.name, func(t *testing.T) {
+			dbConfig := config.DBConfig{Host: "db.local", Port: 5432, Name: "lib", User: tt.user, Password: tt.password, SSLMode: "require"}
+
+			u, err := url.Parse(dbConfig.URL())
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.user, u.User.Username())
+			password, _ := u.User.Password()
+			assert.Equal(t, tt.password, password)
+			assert.Equal(t, "db.local:5432", u.Host)
+			assert.Equal(t, "/lib", u.Path)
+			assert.Equal(t, "require", u.Query().Get("sslmode"))
+		})
+	}
+}
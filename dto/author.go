@@ -4,3 +4,13 @@ type AuthorResponse struct {
 	Id   int    `json:"id"`
 	Name string `json:"name"`
 }
+
+type AuthorRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type AuthorBooksResponse struct {
+	Id    int             `json:"id"`
+	Name  string          `json:"name"`
+	Books []*BookResponse `json:"books"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type BookResponse struct {
 	Id          int             `json:"id"`
 	Author      *AuthorResponse `json:"author,omitempty"`
@@ -7,6 +9,13 @@ type BookResponse struct {
 	Description string          `json:"description"`
 	Quantity    int             `json:"quantity"`
 	Cover       string          `json:"cover,omitempty"`
+	Rank        float64         `json:"rank,omitempty"`
+	Highlight   *BookHighlight  `json:"highlight,omitempty"`
+}
+
+type BookHighlight struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
 }
 
 type BookRequest struct {
@@ -16,3 +25,46 @@ type BookRequest struct {
 	Quantity    *int    `json:"quantity" binding:"required,gte=0"`
 	Cover       *string `json:"cover"`
 }
+
+// BookUpdateRequest replaces the details of a book; its stock is managed through its copies.
+type BookUpdateRequest struct {
+	Title       string  `json:"title" binding:"required,max=35"`
+	AuthorId    *int    `json:"author_id" binding:"required,gt=0"`
+	Description string  `json:"description" binding:"required"`
+	Cover       *string `json:"cover"`
+}
+
+type BookPatchRequest struct {
+	Title       *string `json:"title" binding:"omitempty,max=35"`
+	AuthorId    *int    `json:"author_id" binding:"omitempty,gt=0"`
+	Description *string `json:"description"`
+	Cover       *string `json:"cover"`
+}
+
+type BookListQuery struct {
+	Title         string     `form:"title" json:"title"`
+	AuthorId      *int       `form:"author_id" json:"author_id" binding:"omitempty,gt=0"`
+	AuthorName    string     `form:"author_name" json:"author_name"`
+	Available     *bool      `form:"available" json:"available"`
+	QuantityMin   *int       `form:"quantity_min" json:"quantity_min" binding:"omitempty,gte=0"`
+	QuantityMax   *int       `form:"quantity_max" json:"quantity_max" binding:"omitempty,gte=0"`
+	HasCover      *bool      `form:"has_cover" json:"has_cover"`
+	CreatedAfter  *time.Time `form:"created_after" json:"created_after" time_format:"2006-01-02" time_utc:"1"`
+	CreatedBefore *time.Time `form:"created_before" json:"created_before" time_format:"2006-01-02" time_utc:"1"`
+	Limit         *int       `form:"limit" json:"limit" binding:"omitempty,gte=1,lte=100"`
+	Cursor        string     `form:"cursor" json:"cursor"`
+	Page          *int       `form:"page" json:"page" binding:"omitempty,gte=1"`
+	PerPage       *int       `form:"per_page" json:"per_page" binding:"omitempty,gte=1,lte=100"`
+	Sort          string     `form:"sort" json:"sort"`
+}
+
+type BookPageResponse struct {
+	Data       []*BookResponse    `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+type BookSearchQuery struct {
+	Q       string `form:"q" json:"q" binding:"required"`
+	Page    *int   `form:"page" json:"page" binding:"omitempty,gte=1"`
+	PerPage *int   `form:"per_page" json:"per_page" binding:"omitempty,gte=1,lte=100"`
+}
@@ -6,17 +6,77 @@ type BorrowResponse struct {
 	Id            int        `json:"id"`
 	UserId        int        `json:"user_id"`
 	BookId        int        `json:"book_id"`
+	CopyId        int        `json:"copy_id,omitempty"`
 	Status        string     `json:"status"`
 	BorrowingDate time.Time  `json:"borrowing_date"`
 	ReturningDate *time.Time `json:"returning_date,omitempty"`
+	DueDate       time.Time  `json:"due_date"`
+	DaysOverdue   int        `json:"days_overdue"`
+	RenewalCount  int        `json:"renewal_count"`
+	FineAmount    int64      `json:"fine_amount,omitempty"`
+	CheckedOutBy  *int       `json:"checked_out_by,omitempty"`
 }
 
+type BorrowListQuery struct {
+	UserId         *int       `form:"user_id" json:"user_id" binding:"omitempty,gt=0"`
+	BookId         *int       `form:"book_id" json:"book_id" binding:"omitempty,gt=0"`
+	Status         string     `form:"status" json:"status" binding:"omitempty,oneof=borrowed returned overdue lost damaged_on_return claimed_returned"`
+	BorrowedAfter  *time.Time `form:"borrowed_after" json:"borrowed_after" time_format:"2006-01-02" time_utc:"1"`
+	BorrowedBefore *time.Time `form:"borrowed_before" json:"borrowed_before" time_format:"2006-01-02" time_utc:"1"`
+	Page           *int       `form:"page" json:"page" binding:"omitempty,gte=1"`
+	PerPage        *int       `form:"per_page" json:"per_page" binding:"omitempty,gte=1,lte=100"`
+}
+
+type BorrowPageResponse struct {
+	Data       []BorrowResponse   `json:"data"`
+	Pagination PaginationResponse `json:"pagination"`
+}
+
+// BorrowRequest lends a book to UserId; members always borrow for themselves,
+// while a librarian checking out at the desk names the member in user_id.
 type BorrowRequest struct {
-	BookId *int `json:"book_id" binding:"required,gt=0"`
-	UserId int  `json:"user_id"`
+	BookId       *int   `json:"book_id" binding:"required_without=Barcode,omitempty,gt=0"`
+	Barcode      string `json:"barcode"`
+	UserId       int    `json:"user_id" binding:"omitempty,gt=0"`
+	CheckedOutBy *int   `json:"-"`
 }
 
 type ReturnRequest struct {
-	Id     *int `json:"id" binding:"required,gt=0"`
-	UserId int  `json:"user_id"`
+	Id      *int   `json:"id" binding:"required_without=Barcode,omitempty,gt=0"`
+	Barcode string `json:"barcode"`
+	UserId  int    `json:"user_id"`
+}
+
+type BorrowStatusRequest struct {
+	Status            string `json:"status" binding:"required,oneof=returned lost damaged_on_return claimed_returned"`
+	ReplacementCharge int64  `json:"replacement_charge" binding:"omitempty,gt=0"`
+	Note              string `json:"note" binding:"max=255"`
+}
+
+type BatchBorrowRequest struct {
+	BookIds []int  `json:"book_ids" binding:"required,gt=0,lte=20,dive,gt=0"`
+	Mode    string `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	UserId  int    `json:"-"`
+}
+
+type BatchReturnRequest struct {
+	Ids    []int  `json:"ids" binding:"required,gt=0,lte=20,dive,gt=0"`
+	Mode   string `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	UserId int    `json:"-"`
+}
+
+type BatchItemResponse struct {
+	Id     int             `json:"id,omitempty"`
+	BookId int             `json:"book_id,omitempty"`
+	Status string          `json:"status"`
+	Data   *BorrowResponse `json:"data,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Mode      string              `json:"mode"`
+	Committed bool                `json:"committed"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Items     []BatchItemResponse `json:"items"`
 }
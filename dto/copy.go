@@ -0,0 +1,22 @@
+package dto
+
+type CopyResponse struct {
+	Id        int    `json:"id"`
+	BookId    int    `json:"book_id"`
+	Barcode   string `json:"barcode"`
+	Condition string `json:"condition"`
+	Location  string `json:"location"`
+	Status    string `json:"status"`
+}
+
+type CopyRequest struct {
+	Barcode   string `json:"barcode" binding:"required,max=64"`
+	Condition string `json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	Location  string `json:"location"`
+}
+
+type CopyPatchRequest struct {
+	Condition *string `json:"condition" binding:"omitempty,oneof=new good fair poor"`
+	Location  *string `json:"location"`
+	Status    *string `json:"status" binding:"omitempty,oneof=available withdrawn"`
+}
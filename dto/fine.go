@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type FineEntryResponse struct {
+	Id        int       `json:"id"`
+	BorrowId  *int      `json:"borrowing_record_id,omitempty"`
+	Kind      string    `json:"kind"`
+	Amount    int64     `json:"amount"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FinesResponse struct {
+	Balance int64               `json:"balance"`
+	Entries []FineEntryResponse `json:"entries"`
+}
+
+type FineSettlementRequest struct {
+	Amount int64  `json:"amount" binding:"required,gt=0"`
+	Note   string `json:"note" binding:"max=255"`
+}
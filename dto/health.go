@@ -0,0 +1,21 @@
+package dto
+
+type ComponentStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+type StatusResponse struct {
+	Status     string            `json:"status"`
+	Uptime     string            `json:"uptime"`
+	Build      BuildInfo         `json:"build"`
+	Components []ComponentStatus `json:"components"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+type HoldResponse struct {
+	Id        int        `json:"id"`
+	BookId    int        `json:"book_id"`
+	UserId    int        `json:"user_id"`
+	CopyId    int        `json:"copy_id,omitempty"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type PaginationResponse struct {
+	Total      int             `json:"total"`
+	Page       int             `json:"page,omitempty"`
+	PerPage    int             `json:"per_page,omitempty"`
+	Limit      int             `json:"limit,omitempty"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Links      PaginationLinks `json:"links"`
+}
+
+type PaginationLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
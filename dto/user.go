@@ -1,10 +1,38 @@
 package dto
 
+import "time"
+
 type AuthRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
 
 type AuthResponse struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string    `json:"refresh_token"`
+	All          bool      `json:"all"`
+	UserId       int       `json:"-"`
+	TokenId      string    `json:"-"`
+	ExpiresAt    time.Time `json:"-"`
+}
+
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required,max=35"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+type UserResponse struct {
+	Id       int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
 }
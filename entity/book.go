@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type Book struct {
 	Id          int
 	Author      *Author
@@ -32,3 +34,39 @@ func (bp BookPost) ConvertToBook() *Book {
 
 	return &book
 }
+
+type BookQuery struct {
+	Title         string
+	AuthorId      *int
+	AuthorName    string
+	Available     *bool
+	QuantityMin   *int
+	QuantityMax   *int
+	HasCover      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	SortBy        string
+	SortDesc      bool
+	Limit         int
+	Offset        int
+	Cursor        *BookCursor
+}
+
+type BookCursor struct {
+	Id       int
+	Title    string
+	Quantity int
+}
+
+type BookSearch struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+type BookSearchResult struct {
+	Book
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
@@ -2,11 +2,87 @@ package entity
 
 import "time"
 
+const (
+	BorrowStatusBorrowed        = "borrowed"
+	BorrowStatusReturned        = "returned"
+	BorrowStatusOverdue         = "overdue"
+	BorrowStatusLost            = "lost"
+	BorrowStatusDamagedOnReturn = "damaged_on_return"
+	BorrowStatusClaimedReturned = "claimed_returned"
+)
+
+// borrowTransitions lists the statuses a borrowing record can move to from each status;
+// returned and damaged_on_return records are final.
+var borrowTransitions = map[string][]string{
+	BorrowStatusBorrowed:        {BorrowStatusReturned, BorrowStatusLost, BorrowStatusDamagedOnReturn, BorrowStatusClaimedReturned},
+	BorrowStatusClaimedReturned: {BorrowStatusReturned, BorrowStatusLost, BorrowStatusDamagedOnReturn},
+	BorrowStatusLost:            {BorrowStatusReturned},
+}
+
+// a batch runs all-or-nothing in atomic mode and keeps the items that succeed in best-effort mode
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+const (
+	BatchItemSucceeded  = "succeeded"
+	BatchItemFailed     = "failed"
+	BatchItemRolledBack = "rolled_back"
+	BatchItemSkipped    = "skipped"
+)
+
 type Borrow struct {
 	Id            int
 	UserId        int
 	BookId        int
+	CopyId        int
 	Status        string
 	BorrowingDate time.Time
 	ReturningDate time.Time
+	DueDate       time.Time
+	DaysOverdue   int
+	RenewalCount  int
+	CheckedOutBy  *int
+}
+
+// IsOpen tells whether the copy is still out with the borrower, including a loan reported as overdue.
+func (b Borrow) IsOpen() bool {
+	return b.Status == BorrowStatusBorrowed || b.Status == BorrowStatusOverdue || b.Status == BorrowStatusClaimedReturned
+}
+
+func (b Borrow) CanTransitionTo(status string) bool {
+	from := b.Status
+	if from == BorrowStatusOverdue {
+		from = BorrowStatusBorrowed
+	}
+
+	for _, to := range borrowTransitions[from] {
+		if to == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ClosesLoan tells whether moving to the status ends the loan, setting its returning date.
+func ClosesLoan(status string) bool {
+	return status != BorrowStatusBorrowed && status != BorrowStatusClaimedReturned
+}
+
+type Renewal struct {
+	Id              int
+	BorrowId        int
+	PreviousDueDate time.Time
+	NewDueDate      time.Time
+}
+
+type BorrowQuery struct {
+	UserId         *int
+	BookId         *int
+	Status         string
+	BorrowedAfter  *time.Time
+	BorrowedBefore *time.Time
+	Limit          int
+	Offset         int
 }
@@ -0,0 +1,26 @@
+package entity
+
+const (
+	CopyStatusAvailable = "available"
+	CopyStatusBorrowed  = "borrowed"
+	CopyStatusReserved  = "reserved"
+	CopyStatusWithdrawn = "withdrawn"
+	CopyStatusLost      = "lost"
+	CopyStatusDamaged   = "damaged"
+)
+
+const (
+	CopyConditionNew  = "new"
+	CopyConditionGood = "good"
+	CopyConditionFair = "fair"
+	CopyConditionPoor = "poor"
+)
+
+type BookCopy struct {
+	Id        int
+	BookId    int
+	Barcode   string
+	Condition string
+	Location  string
+	Status    string
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	FineKindCharge  = "charge"
+	FineKindPayment = "payment"
+	FineKindWaiver  = "waiver"
+)
+
+// FineEntry is a line of the fines ledger. Amounts are in minor currency units
+// and always positive; the kind tells whether it adds to or settles the debt.
+type FineEntry struct {
+	Id        int
+	UserId    int
+	BorrowId  *int
+	Kind      string
+	Amount    int64
+	Note      string
+	CreatedBy *int
+	CreatedAt time.Time
+}
@@ -0,0 +1,7 @@
+package entity
+
+const (
+	HealthUp       = "up"
+	HealthDown     = "down"
+	HealthDraining = "draining"
+)
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusReady     = "ready"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
+
+type Hold struct {
+	Id        int
+	BookId    int
+	UserId    int
+	CopyId    int
+	Status    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (h Hold) IsActive() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
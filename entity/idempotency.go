@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// IdempotencyKey remembers a write request sent with an Idempotency-Key header so that a retry
+// replays the stored response; StatusCode is 0 while the first request is still in flight.
+// Reservation tells apart the requests that held the key one after the other.
+type IdempotencyKey struct {
+	UserId       int
+	Key          string
+	Reservation  int64
+	Fingerprint  string
+	StatusCode   int
+	ResponseBody []byte
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	NotificationDueSoon = "due_soon"
+	NotificationOverdue = "overdue"
+)
+
+// Reminder is a loan whose borrower has not been notified yet that it is due soon or overdue.
+// NotificationId and Attempts are set once the reminder is claimed for delivery.
+type Reminder struct {
+	BorrowId       int
+	UserId         int
+	Username       string
+	Email          string
+	BookTitle      string
+	DueDate        time.Time
+	Kind           string
+	NotificationId int
+	Attempts       int
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type RefreshToken struct {
+	Id        int
+	UserId    int
+	FamilyId  string
+	TokenHash string
+	ExpiresAt time.Time
+	Rotated   bool
+	Revoked   bool
+}
@@ -1,7 +1,14 @@
 package entity
 
+const (
+	RoleLibrarian = "librarian"
+	RoleMember    = "member"
+)
+
 type User struct {
 	Id       int
+	Username string
 	Email    string
 	Password string
+	Role     string
 }
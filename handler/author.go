@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorHandler struct {
+	usecase usecase.AuthorUsecase
+}
+
+func NewAuthorHandler(uc usecase.AuthorUsecase) AuthorHandler {
+	return AuthorHandler{
+		usecase: uc,
+	}
+}
+
+func (h AuthorHandler) GetAuthorsHandler(ctx *gin.Context) {
+	authorsResponse, err := h.usecase.ListAuthors(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": authorsResponse})
+}
+
+func (h AuthorHandler) GetAuthorHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	authorResponse, err := h.usecase.GetAuthor(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": authorResponse})
+}
+
+func (h AuthorHandler) AddAuthorHandler(ctx *gin.Context) {
+	var authorRequest dto.AuthorRequest
+	err := ctx.ShouldBindJSON(&authorRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	authorResponse, err := h.usecase.AddAuthor(ctx, &authorRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": authorResponse})
+}
+
+func (h AuthorHandler) UpdateAuthorHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var authorRequest dto.AuthorRequest
+	err = ctx.ShouldBindJSON(&authorRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	authorResponse, err := h.usecase.UpdateAuthor(ctx, id, &authorRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": authorResponse})
+}
+
+func (h AuthorHandler) DeleteAuthorHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	authorResponse, err := h.usecase.DeleteAuthor(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": authorResponse})
+}
+
+func (h AuthorHandler) GetAuthorBooksHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	authorBooksResponse, err := h.usecase.GetAuthorBooks(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": authorBooksResponse})
+}
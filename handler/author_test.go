@@ -0,0 +1,229 @@
+package handler_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/handler"
+	"archive_lib/middleware"
+	"archive_lib/mocks"
+	"archive_lib/util"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	authorsResponse     = []*dto.AuthorResponse{authorResponse}
+	authorRequest       = &dto.AuthorRequest{Name: "John The Poet"}
+	authorBooksResponse = &dto.AuthorBooksResponse{
+		Id:    authorId,
+		Name:  "John The Poet",
+		Books: []*dto.BookResponse{bookResponse},
+	}
+)
+
+func TestGetAuthorsHandler(t *testing.T) {
+	t.Run("should return StatusOK with list of authors when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("ListAuthors", ctx).Return(authorsResponse, nil)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.GET("/authors", authorHandler.GetAuthorsHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": authorsResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/authors", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusInternalServerError when list authors encounters server error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("ListAuthors", ctx).Return(nil, errors.New("server error"))
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/authors", authorHandler.GetAuthorsHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Server error"})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/authors", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestGetAuthorHandler(t *testing.T) {
+	t.Run("should return StatusOK with the author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("GetAuthor", ctx, authorId).Return(authorResponse, nil)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.GET("/authors/:id", authorHandler.GetAuthorHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": authorResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/authors/5", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusNotFound when getting non-existing author", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("GetAuthor", ctx, authorId).Return(nil, apperror.ErrAuthorNotFound{})
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/authors/:id", authorHandler.GetAuthorHandler)
+		fieldErrors := []util.FieldError{{Field: "author_id", Message: "Not found"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/authors/5", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestAddAuthorHandler(t *testing.T) {
+	t.Run("should return StatusCreated with the added author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("AddAuthor", ctx, authorRequest).Return(authorResponse, nil)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.POST("/authors", authorHandler.AddAuthorHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": authorResponse})
+		authorRequestJSON, _ := json.Marshal(*authorRequest)
+		body := strings.NewReader(string(authorRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/authors", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when adding author with empty name", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/authors", authorHandler.AddAuthorHandler)
+		fieldErrors := []util.FieldError{{Field: "Name", Message: "Required"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+		authorRequestJSON, _ := json.Marshal(dto.AuthorRequest{})
+		body := strings.NewReader(string(authorRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/authors", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestUpdateAuthorHandler(t *testing.T) {
+	t.Run("should return StatusOK with the updated author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("UpdateAuthor", ctx, authorId, authorRequest).Return(authorResponse, nil)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.PUT("/authors/:id", authorHandler.UpdateAuthorHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": authorResponse})
+		authorRequestJSON, _ := json.Marshal(*authorRequest)
+		body := strings.NewReader(string(authorRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPut, "/authors/5", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestDeleteAuthorHandler(t *testing.T) {
+	t.Run("should return StatusOK with the deleted author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("DeleteAuthor", ctx, authorId).Return(authorResponse, nil)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.DELETE("/authors/:id", authorHandler.DeleteAuthorHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": authorResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodDelete, "/authors/5", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when deleting author with active books", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("DeleteAuthor", ctx, authorId).Return(nil, apperror.ErrAuthorHasBooks{})
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.DELETE("/authors/:id", authorHandler.DeleteAuthorHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Author still has books in the catalogue"})
+
+		ctx.Request, _ = http.NewRequest(http.MethodDelete, "/authors/5", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestGetAuthorBooksHandler(t *testing.T) {
+	t.Run("should return StatusOK with the author bibliography when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		mockAuthorUsecase.On("GetAuthorBooks", ctx, authorId).Return(authorBooksResponse, nil)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.GET("/authors/:id/books", authorHandler.GetAuthorBooksHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": authorBooksResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/authors/5/books", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when getting bibliography with invalid id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockAuthorUsecase := new(mocks.AuthorUsecase)
+		authorHandler := handler.NewAuthorHandler(mockAuthorUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/authors/:id/books", authorHandler.GetAuthorBooksHandler)
+		fieldErrors := []util.FieldError{{Field: "id", Message: "Invalid id"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/authors/abc/books", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"archive_lib/apperror"
 	"archive_lib/dto"
 	"archive_lib/usecase"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -18,7 +20,34 @@ func NewBookHandler(uc usecase.BookUsecase) BookHandler {
 	}
 }
 
+func (h BookHandler) hasListOptions(query *dto.BookListQuery) bool {
+	hasFilter := query.AuthorId != nil || query.AuthorName != "" || query.Available != nil ||
+		query.QuantityMin != nil || query.QuantityMax != nil || query.HasCover != nil ||
+		query.CreatedAfter != nil || query.CreatedBefore != nil
+	hasPagination := query.Limit != nil || query.Cursor != "" || query.Page != nil || query.PerPage != nil || query.Sort != ""
+
+	return hasFilter || hasPagination
+}
+
 func (h BookHandler) GetBooksHandler(ctx *gin.Context) {
+	var bookListQuery dto.BookListQuery
+	err := ctx.ShouldBindQuery(&bookListQuery)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if h.hasListOptions(&bookListQuery) {
+		bookPageResponse, err := h.usecase.ListBooksPage(ctx, &bookListQuery)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		bookPageResponse.Pagination.Links = buildPageLinks(ctx, &bookPageResponse.Pagination)
+		ctx.JSON(http.StatusOK, bookPageResponse)
+		return
+	}
+
 	if title, found := ctx.GetQuery("title"); found {
 		booksResponse, err := h.usecase.GetBooksByTitle(ctx, title)
 		if err != nil {
@@ -37,6 +66,23 @@ func (h BookHandler) GetBooksHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": booksResponse})
 }
 
+func (h BookHandler) SearchBooksHandler(ctx *gin.Context) {
+	var bookSearchQuery dto.BookSearchQuery
+	err := ctx.ShouldBindQuery(&bookSearchQuery)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	bookPageResponse, err := h.usecase.SearchBooks(ctx, &bookSearchQuery)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	bookPageResponse.Pagination.Links = buildPageLinks(ctx, &bookPageResponse.Pagination)
+	ctx.JSON(http.StatusOK, bookPageResponse)
+}
+
 func (h BookHandler) AddBookHandler(ctx *gin.Context) {
 	var bookRequest dto.BookRequest
 	err := ctx.ShouldBindJSON(&bookRequest)
@@ -53,3 +99,81 @@ func (h BookHandler) AddBookHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusCreated, gin.H{"data": bookResponse})
 }
+
+func (h BookHandler) UpdateBookHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var bookUpdateRequest dto.BookUpdateRequest
+	err = ctx.ShouldBindJSON(&bookUpdateRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	bookResponse, err := h.usecase.UpdateBook(ctx, id, &bookUpdateRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": bookResponse})
+}
+
+func (h BookHandler) PatchBookHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var bookPatchRequest dto.BookPatchRequest
+	err = ctx.ShouldBindJSON(&bookPatchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	bookResponse, err := h.usecase.PatchBook(ctx, id, &bookPatchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": bookResponse})
+}
+
+func (h BookHandler) DeleteBookHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	bookResponse, err := h.usecase.DeleteBook(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": bookResponse})
+}
+
+func (h BookHandler) RestoreBookHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	bookResponse, err := h.usecase.RestoreBook(ctx, id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": bookResponse})
+}
@@ -9,10 +9,12 @@ import (
 	"archive_lib/util"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +55,13 @@ var (
 		Quantity:    &quantity,
 		Cover:       &cover,
 	}
+
+	bookUpdateRequest = &dto.BookUpdateRequest{
+		Title:       "Test Book",
+		AuthorId:    &authorId,
+		Description: "Cool book",
+		Cover:       &cover,
+	}
 )
 
 func TestGetBooksHandler(t *testing.T) {
@@ -123,6 +132,201 @@ func TestGetBooksHandler(t *testing.T) {
 	})
 }
 
+func TestGetBooksPageHandler(t *testing.T) {
+	t.Run("should return StatusOK with page of books and pagination links when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		page, perPage := 2, 1
+		bookListQuery := &dto.BookListQuery{Page: &page, PerPage: &perPage}
+		bookPageResponse := &dto.BookPageResponse{
+			Data:       booksWithAuthorResponse,
+			Pagination: dto.PaginationResponse{Total: 3, Page: 2, PerPage: 1},
+		}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("ListBooksPage", ctx, bookListQuery).Return(bookPageResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.GET("/books", bookHandler.GetBooksHandler)
+		expectedResponse, _ := json.Marshal(&dto.BookPageResponse{
+			Data: booksWithAuthorResponse,
+			Pagination: dto.PaginationResponse{
+				Total:   3,
+				Page:    2,
+				PerPage: 1,
+				Links: dto.PaginationLinks{
+					Self:  "/books?page=2&per_page=1",
+					First: "/books?page=1&per_page=1",
+					Prev:  "/books?page=1&per_page=1",
+					Next:  "/books?page=3&per_page=1",
+					Last:  "/books?page=3&per_page=1",
+				},
+			},
+		})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?page=2&per_page=1", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return next link with cursor when using cursor pagination", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		limit := 1
+		bookListQuery := &dto.BookListQuery{Limit: &limit}
+		bookPageResponse := &dto.BookPageResponse{
+			Data:       booksWithAuthorResponse,
+			Pagination: dto.PaginationResponse{Total: 3, Limit: 1, NextCursor: "abc"},
+		}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("ListBooksPage", ctx, bookListQuery).Return(bookPageResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.GET("/books", bookHandler.GetBooksHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?limit=1", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"next":"/books?cursor=abc\u0026limit=1"`)
+	})
+
+	t.Run("should return StatusBadRequest when page is not a number", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/books", bookHandler.GetBooksHandler)
+		errDecodeResponse := []util.FieldError{{Field: "", Message: "Mismatch data type or malformed request"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": errDecodeResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?page=abc", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when sorting by unsupported field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		bookListQuery := &dto.BookListQuery{Sort: "cover"}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("ListBooksPage", ctx, bookListQuery).Return(nil, apperror.ErrInvalidSort{})
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/books", bookHandler.GetBooksHandler)
+		fieldErrors := []util.FieldError{{Field: "sort", Message: "Unsupported sort field"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?sort=cover", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestGetBooksFilterHandler(t *testing.T) {
+	t.Run("should return StatusOK with filtered books when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		available := true
+		createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		bookListQuery := &dto.BookListQuery{AuthorId: &authorId, Available: &available, CreatedAfter: &createdAfter}
+		bookPageResponse := &dto.BookPageResponse{
+			Data:       booksWithAuthorResponse,
+			Pagination: dto.PaginationResponse{Total: 1, Page: 1, PerPage: 20},
+		}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("ListBooksPage", ctx, bookListQuery).Return(bookPageResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.GET("/books", bookHandler.GetBooksHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?author_id=5&available=true&created_after=2024-01-01", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookUsecase.AssertExpectations(t)
+	})
+
+	t.Run("should return StatusBadRequest when created_after is not a date", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/books", bookHandler.GetBooksHandler)
+		fieldErrors := []util.FieldError{{Field: "", Message: "Should be a date in YYYY-MM-DD format"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?created_after=yesterday", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when quantity range is inverted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		quantityMin, quantityMax := 5, 1
+		bookListQuery := &dto.BookListQuery{QuantityMin: &quantityMin, QuantityMax: &quantityMax}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("ListBooksPage", ctx, bookListQuery).Return(nil, apperror.ErrInvalidQuantityRange{})
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/books", bookHandler.GetBooksHandler)
+		fieldErrors := []util.FieldError{{Field: "quantity_max", Message: "Should not be less than quantity_min"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books?quantity_min=5&quantity_max=1", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestSearchBooksHandler(t *testing.T) {
+	t.Run("should return StatusOK with matching books when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		bookSearchQuery := &dto.BookSearchQuery{Q: "cool book"}
+		bookPageResponse := &dto.BookPageResponse{
+			Data:       booksWithAuthorResponse,
+			Pagination: dto.PaginationResponse{Total: 1, Page: 1, PerPage: 20},
+		}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("SearchBooks", ctx, bookSearchQuery).Return(bookPageResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.GET("/books/search", bookHandler.SearchBooksHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books/search?q=cool+book", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookUsecase.AssertExpectations(t)
+	})
+
+	t.Run("should return StatusBadRequest when search query is missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/books/search", bookHandler.SearchBooksHandler)
+		fieldErrors := []util.FieldError{{Field: "Q", Message: "Required"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books/search", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
 func TestAddBookHandler(t *testing.T) {
 	t.Run("should return StatusCreated with the added book when no error", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -321,3 +525,251 @@ func TestAddBookHandler(t *testing.T) {
 		assert.Equal(t, string(expectedResponse), w.Body.String())
 	})
 }
+
+func TestUpdateBookHandler(t *testing.T) {
+	t.Run("should return StatusOK with the updated book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("UpdateBook", ctx, 1, bookUpdateRequest).Return(bookResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.PUT("/books/:id", bookHandler.UpdateBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": bookResponse})
+		bookRequestJSON, _ := json.Marshal(*bookUpdateRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPut, "/books/1", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when updating book with invalid id", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PUT("/books/:id", bookHandler.UpdateBookHandler)
+		fieldErrors := []util.FieldError{{Field: "id", Message: "Invalid id"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+		bookRequestJSON, _ := json.Marshal(*bookUpdateRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPut, "/books/abc", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusNotFound when updating non-existing book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("UpdateBook", ctx, 1, bookUpdateRequest).Return(nil, apperror.ErrBookNotFound{})
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PUT("/books/:id", bookHandler.UpdateBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Book not found"})
+		bookRequestJSON, _ := json.Marshal(*bookUpdateRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPut, "/books/1", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should leave the quantity out of the update of a book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("UpdateBook", ctx, 1, bookUpdateRequest).Return(bookResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.PUT("/books/:id", bookHandler.UpdateBookHandler)
+		bookRequestJSON, _ := json.Marshal(*bookRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPut, "/books/1", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockBookUsecase.AssertCalled(t, "UpdateBook", ctx, 1, bookUpdateRequest)
+	})
+}
+
+func TestPatchBookHandler(t *testing.T) {
+	t.Run("should return StatusOK with the patched book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		newDescription := "New description"
+		bookPatchRequest := &dto.BookPatchRequest{Description: &newDescription}
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("PatchBook", ctx, 1, bookPatchRequest).Return(bookResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.PATCH("/books/:id", bookHandler.PatchBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": bookResponse})
+		bookPatchRequestJSON, _ := json.Marshal(*bookPatchRequest)
+		body := strings.NewReader(string(bookPatchRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/books/1", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when patching book with too long title", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		invalidTitle := strings.Repeat("a", 36)
+		bookPatchRequest := &dto.BookPatchRequest{Title: &invalidTitle}
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/books/:id", bookHandler.PatchBookHandler)
+		fieldErrors := []util.FieldError{{Field: "Title", Message: "Should be less than 35 characters"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+		bookPatchRequestJSON, _ := json.Marshal(*bookPatchRequest)
+		body := strings.NewReader(string(bookPatchRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/books/1", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestDeleteBookHandler(t *testing.T) {
+	t.Run("should return StatusOK with the deleted book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("DeleteBook", ctx, 1).Return(bookResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.DELETE("/books/:id", bookHandler.DeleteBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": bookResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodDelete, "/books/1", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusNotFound when deleting non-existing book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("DeleteBook", ctx, 1).Return(nil, apperror.ErrBookNotFound{})
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.DELETE("/books/:id", bookHandler.DeleteBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Book not found"})
+
+		ctx.Request, _ = http.NewRequest(http.MethodDelete, "/books/1", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestRestoreBookHandler(t *testing.T) {
+	t.Run("should return StatusOK with the restored book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("RestoreBook", ctx, 1).Return(bookResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.POST("/books/:id/restore", bookHandler.RestoreBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": bookResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/restore", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when restoring an active book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("RestoreBook", ctx, 1).Return(nil, apperror.ErrBookNotDeleted{})
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books/:id/restore", bookHandler.RestoreBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Book has not been deleted"})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/restore", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestAddBookHandlerAuthorization(t *testing.T) {
+	t.Run("should return StatusCreated when a librarian adds a book", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		mockBookUsecase.On("AddBook", ctx, bookRequest).Return(bookResponse, nil)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books", middleware.AuthMiddleware, middleware.RequireRole("librarian"), bookHandler.AddBookHandler)
+		bookRequestJSON, _ := json.Marshal(*bookRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("should return StatusForbidden when a member adds a book", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books", middleware.AuthMiddleware, middleware.RequireRole("librarian"), bookHandler.AddBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Insufficient role for this action"})
+		bookRequestJSON, _ := json.Marshal(*bookRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+		mockBookUsecase.AssertNotCalled(t, "AddBook")
+	})
+
+	t.Run("should return StatusUnauthorized when adding a book without token", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBookUsecase := new(mocks.BookUsecase)
+		bookHandler := handler.NewBookHandler(mockBookUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books", middleware.AuthMiddleware, middleware.RequireRole("librarian"), bookHandler.AddBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": "Login failed"})
+		bookRequestJSON, _ := json.Marshal(*bookRequest)
+		body := strings.NewReader(string(bookRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
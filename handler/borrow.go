@@ -20,6 +20,70 @@ func NewBorrowHandler(uc usecase.BorrowUsecase) BorrowHandler {
 	}
 }
 
+func (h BorrowHandler) GetBorrowsHandler(ctx *gin.Context) {
+	var query dto.BorrowListQuery
+	err := ctx.ShouldBindQuery(&query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	borrowPageResponse, err := h.usecase.ListBorrows(ctx, &query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	borrowPageResponse.Pagination.Links = buildPageLinks(ctx, &borrowPageResponse.Pagination)
+	ctx.JSON(http.StatusOK, borrowPageResponse)
+}
+
+func (h BorrowHandler) GetMyBorrowsHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	var query dto.BorrowListQuery
+	err = ctx.ShouldBindQuery(&query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	borrowPageResponse, err := h.usecase.ListUserBorrows(ctx, userId, &query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	borrowPageResponse.Pagination.Links = buildPageLinks(ctx, &borrowPageResponse.Pagination)
+	ctx.JSON(http.StatusOK, borrowPageResponse)
+}
+
+func (h BorrowHandler) GetBorrowHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	borrowResponse, err := h.usecase.GetBorrow(ctx, id, userId, ctx.GetString("role"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": borrowResponse})
+}
+
 func (h BorrowHandler) BorrowBookHandler(ctx *gin.Context) {
 	rawUserId, found := ctx.Get("subject")
 	if !found {
@@ -34,12 +98,12 @@ func (h BorrowHandler) BorrowBookHandler(ctx *gin.Context) {
 	}
 
 	var borrowRequest dto.BorrowRequest
-	borrowRequest.UserId = userId
 	err = ctx.ShouldBindJSON(&borrowRequest)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
+	borrowRequest.UserId = userId
 
 	borrowResponse, err := h.usecase.Record(ctx, &borrowRequest)
 	if err != nil {
@@ -50,6 +114,33 @@ func (h BorrowHandler) BorrowBookHandler(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, gin.H{"data": borrowResponse})
 }
 
+func (h BorrowHandler) CheckoutHandler(ctx *gin.Context) {
+	staffId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	var borrowRequest dto.BorrowRequest
+	err = ctx.ShouldBindJSON(&borrowRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if borrowRequest.UserId == 0 {
+		ctx.Error(apperror.ErrBorrowerRequired{})
+		return
+	}
+
+	borrowResponse, err := h.usecase.Checkout(ctx, &borrowRequest, staffId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": borrowResponse})
+}
+
 func (h BorrowHandler) ReturnBookHandler(ctx *gin.Context) {
 	rawUserId, found := ctx.Get("subject")
 	if !found {
@@ -64,12 +155,12 @@ func (h BorrowHandler) ReturnBookHandler(ctx *gin.Context) {
 	}
 
 	var returnRequest dto.ReturnRequest
-	returnRequest.UserId = userId
 	err = ctx.ShouldBindJSON(&returnRequest)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
+	returnRequest.UserId = userId
 
 	returnResponse, err := h.usecase.Return(ctx, &returnRequest)
 	if err != nil {
@@ -79,3 +170,113 @@ func (h BorrowHandler) ReturnBookHandler(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"data": returnResponse})
 }
+
+// batchStatus reports a partly failed best-effort batch with 207 and a rolled back atomic batch with 422.
+func batchStatus(batchResponse *dto.BatchResponse, successStatus int) int {
+	if !batchResponse.Committed {
+		return http.StatusUnprocessableEntity
+	}
+	if batchResponse.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+	return successStatus
+}
+
+func (h BorrowHandler) BatchBorrowHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	var batchRequest dto.BatchBorrowRequest
+	err = ctx.ShouldBindJSON(&batchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	batchRequest.UserId = userId
+
+	batchResponse, err := h.usecase.BatchRecord(ctx, &batchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(batchStatus(batchResponse, http.StatusCreated), gin.H{"data": batchResponse})
+}
+
+func (h BorrowHandler) BatchReturnHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	var batchRequest dto.BatchReturnRequest
+	err = ctx.ShouldBindJSON(&batchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	batchRequest.UserId = userId
+
+	batchResponse, err := h.usecase.BatchReturn(ctx, &batchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(batchStatus(batchResponse, http.StatusOK), gin.H{"data": batchResponse})
+}
+
+func (h BorrowHandler) RenewBorrowHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	borrowResponse, err := h.usecase.Renew(ctx, id, userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": borrowResponse})
+}
+
+func (h BorrowHandler) UpdateBorrowStatusHandler(ctx *gin.Context) {
+	staffId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var statusRequest dto.BorrowStatusRequest
+	err = ctx.ShouldBindJSON(&statusRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	borrowResponse, err := h.usecase.UpdateStatus(ctx, id, staffId, &statusRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": borrowResponse})
+}
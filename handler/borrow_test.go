@@ -3,10 +3,15 @@ package handler_test
 import (
 	"archive_lib/apperror"
 	"archive_lib/dto"
+	"archive_lib/entity"
 	"archive_lib/handler"
 	"archive_lib/middleware"
 	"archive_lib/mocks"
 	"archive_lib/util"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -17,6 +22,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -53,13 +59,12 @@ var (
 
 func TestBorrowHandler(t *testing.T) {
 	t.Run("should return StatusCreated with borrowed book when no error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("1")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("1", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
-		mockBorrowUsecase.On("Record", ctx, borrowRequest).Return(borrowResponse, nil)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil)
 		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
 		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
 		expectedResponse, _ := json.Marshal(gin.H{"data": borrowResponse})
@@ -74,8 +79,25 @@ func TestBorrowHandler(t *testing.T) {
 		assert.Equal(t, string(expectedResponse), w.Body.String())
 	})
 
+	t.Run("should borrow for the token subject even when the body names another user", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1,"user_id":2}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockBorrowUsecase.AssertCalled(t, "Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1})
+	})
+
 	t.Run("should return error when get subject from context encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
@@ -92,12 +114,12 @@ func TestBorrowHandler(t *testing.T) {
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		assert.Equal(t, string(expectedResponse), w.Body.String())
+		mockBorrowUsecase.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
 	})
 
 	t.Run("should return error when user id conversion to string encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
@@ -117,13 +139,12 @@ func TestBorrowHandler(t *testing.T) {
 	})
 
 	t.Run("should return StatusBadRequest when borrow request encounters decode error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("1")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("1", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
-		mockBorrowUsecase.On("Record", ctx, borrowRequest).Return(borrowResponse, nil)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil)
 		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
 		router.Use(middleware.ErrorMiddleware)
 		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
@@ -141,13 +162,12 @@ func TestBorrowHandler(t *testing.T) {
 	})
 
 	t.Run("should return error when record borrow encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("0")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("0", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
-		mockBorrowUsecase.On("Record", ctx, borrowRequest).Return(nil, apperror.ErrRequestUnrecognized{})
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 0}).Return(nil, apperror.ErrRequestUnrecognized{})
 		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
 		router.Use(middleware.ErrorMiddleware)
 		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
@@ -164,15 +184,98 @@ func TestBorrowHandler(t *testing.T) {
 	})
 }
 
+func TestCheckoutHandler(t *testing.T) {
+	t.Run("should return StatusCreated with the loan of the named member when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		staffId := 2
+		checkoutResponse := *borrowResponse
+		checkoutResponse.UserId = 3
+		checkoutResponse.CheckedOutBy = &staffId
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Checkout", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 3}, staffId).Return(&checkoutResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/checkout", middleware.AuthMiddleware, borrowHandler.CheckoutHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": checkoutResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/checkout", strings.NewReader(`{"book_id":1,"user_id":3}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the member is not named", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/checkout", middleware.AuthMiddleware, borrowHandler.CheckoutHandler)
+		fieldErrors := []util.FieldError{{Field: "user_id", Message: "Required"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/checkout", strings.NewReader(`{"book_id":1}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+		mockBorrowUsecase.AssertNotCalled(t, "Checkout", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return StatusNotFound when the member does not exist", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Checkout", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 3}, 2).Return(nil, apperror.ErrUserNotFound{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/checkout", middleware.AuthMiddleware, borrowHandler.CheckoutHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrUserNotFound{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/checkout", strings.NewReader(`{"book_id":1,"user_id":3}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the borrower is not a member", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Checkout", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 4}, 2).Return(nil, apperror.ErrBorrowerNotMember{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/checkout", middleware.AuthMiddleware, borrowHandler.CheckoutHandler)
+		fieldErrors := []util.FieldError{{Field: "user_id", Message: "Should be a member"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/checkout", strings.NewReader(`{"book_id":1,"user_id":4}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
 func TestReturnHandler(t *testing.T) {
 	t.Run("should return StatusOK with returned book when no error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("1")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("1", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
-		mockBorrowUsecase.On("Return", ctx, returnRequest).Return(returnResponse, nil)
+		mockBorrowUsecase.On("Return", ctx, &dto.ReturnRequest{Id: &recordId, UserId: 1}).Return(returnResponse, nil)
 		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
 		router.PATCH("/borrowing-records", middleware.AuthMiddleware, borrowHandler.ReturnBookHandler)
 		expectedResponse, _ := json.Marshal(gin.H{"data": returnResponse})
@@ -188,7 +291,6 @@ func TestReturnHandler(t *testing.T) {
 	})
 
 	t.Run("should return error when get subject from context encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
@@ -208,9 +310,8 @@ func TestReturnHandler(t *testing.T) {
 	})
 
 	t.Run("should return error when user id conversion to string encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
@@ -230,13 +331,12 @@ func TestReturnHandler(t *testing.T) {
 	})
 
 	t.Run("should return StatusBadRequest when return request encounters decode error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("1")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("1", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
-		mockBorrowUsecase.On("Return", ctx, returnRequest).Return(returnResponse, nil)
+		mockBorrowUsecase.On("Return", ctx, &dto.ReturnRequest{Id: &recordId, UserId: 1}).Return(returnResponse, nil)
 		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
 		router.Use(middleware.ErrorMiddleware)
 		router.PATCH("/borrowing-records", middleware.AuthMiddleware, borrowHandler.ReturnBookHandler)
@@ -254,13 +354,12 @@ func TestReturnHandler(t *testing.T) {
 	})
 
 	t.Run("should return error when return borrowed encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwt := util.NewJWT()
-		token, _ := jwt.GenerateJWT("0")
+		jwt := util.NewJWT(testJWTConfig)
+		token, _ := jwt.GenerateJWT("0", "member")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockBorrowUsecase := new(mocks.BorrowUsecase)
-		mockBorrowUsecase.On("Return", ctx, returnRequest).Return(nil, apperror.ErrRequestUnrecognized{})
+		mockBorrowUsecase.On("Return", ctx, &dto.ReturnRequest{Id: &recordId, UserId: 0}).Return(nil, apperror.ErrRequestUnrecognized{})
 		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
 		router.Use(middleware.ErrorMiddleware)
 		router.PATCH("/borrowing-records", middleware.AuthMiddleware, borrowHandler.ReturnBookHandler)
@@ -276,3 +375,741 @@ func TestReturnHandler(t *testing.T) {
 		assert.Equal(t, string(expectedResponse), w.Body.String())
 	})
 }
+
+func TestGetBorrowsHandler(t *testing.T) {
+	t.Run("should return StatusOK with overdue records when filtering by overdue status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		overduePage := &dto.BorrowPageResponse{
+			Data: []dto.BorrowResponse{
+				{Id: recordId, UserId: 1, BookId: bookId, Status: "overdue", BorrowingDate: borrowingDate, DueDate: borrowingDate.AddDate(0, 0, 14), DaysOverdue: 2},
+			},
+			Pagination: dto.PaginationResponse{Total: 1, Page: 1, PerPage: 20},
+		}
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("ListBorrows", ctx, &dto.BorrowListQuery{Status: "overdue"}).Return(overduePage, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/borrowing-records", borrowHandler.GetBorrowsHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/borrowing-records?status=overdue", nil)
+		router.HandleContext(ctx)
+		expectedResponse, _ := json.Marshal(overduePage)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+		assert.Equal(t, "/borrowing-records?page=1&per_page=20&status=overdue", overduePage.Pagination.Links.First)
+	})
+
+	t.Run("should return StatusBadRequest when filtering by unknown status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/borrowing-records", borrowHandler.GetBorrowsHandler)
+		fieldErrors := []util.FieldError{{Field: "Status", Message: "Should be one of: borrowed returned overdue lost damaged_on_return claimed_returned"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/borrowing-records?status=missing", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the borrowing date range is inverted", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("ListBorrows", ctx, mock.Anything).Return(nil, apperror.ErrInvalidBorrowDateRange{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/borrowing-records", borrowHandler.GetBorrowsHandler)
+		fieldErrors := []util.FieldError{{Field: "borrowed_before", Message: apperror.ErrInvalidBorrowDateRange{}.Error()}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/borrowing-records?borrowed_after=2024-02-01&borrowed_before=2024-01-01", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestGetMyBorrowsHandler(t *testing.T) {
+	t.Run("should return StatusOK with the records of the user", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		page := 2
+		borrowPage := &dto.BorrowPageResponse{
+			Data:       []dto.BorrowResponse{*borrowResponse},
+			Pagination: dto.PaginationResponse{Total: 21, Page: 2, PerPage: 20},
+		}
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("ListUserBorrows", ctx, 1, &dto.BorrowListQuery{Page: &page}).Return(borrowPage, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/me/borrowing-records", middleware.AuthMiddleware, borrowHandler.GetMyBorrowsHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/me/borrowing-records?page=2", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+		expectedResponse, _ := json.Marshal(borrowPage)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+		assert.Equal(t, "/me/borrowing-records?page=1&per_page=20", borrowPage.Pagination.Links.Prev)
+		assert.Empty(t, borrowPage.Pagination.Links.Next)
+	})
+}
+
+func TestGetBorrowHandler(t *testing.T) {
+	t.Run("should return StatusOK with the record when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("GetBorrow", ctx, recordId, 1, "member").Return(borrowResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/borrowing-records/:id", middleware.AuthMiddleware, borrowHandler.GetBorrowHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": borrowResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/borrowing-records/1", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusNotFound when the record does not exist", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("GetBorrow", ctx, recordId, 1, "member").Return(nil, apperror.ErrBorrowNotFound{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/borrowing-records/:id", middleware.AuthMiddleware, borrowHandler.GetBorrowHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrBorrowNotFound{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/borrowing-records/1", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestRenewBorrowHandler(t *testing.T) {
+	t.Run("should return StatusOK with the renewed record when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		renewedResponse := *borrowResponse
+		renewedResponse.RenewalCount = 1
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Renew", ctx, recordId, 1).Return(&renewedResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/:id/renew", middleware.AuthMiddleware, borrowHandler.RenewBorrowHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": renewedResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/1/renew", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the renewal limit is reached", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Renew", ctx, recordId, 1).Return(nil, apperror.ErrRenewalLimitReached{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/:id/renew", middleware.AuthMiddleware, borrowHandler.RenewBorrowHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrRenewalLimitReached{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/1/renew", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusNotFound when the record belongs to another member", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Renew", ctx, recordId, 2).Return(nil, apperror.ErrBorrowNotFound{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/:id/renew", middleware.AuthMiddleware, borrowHandler.RenewBorrowHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrBorrowNotFound{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/1/renew", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestBorrowPolicyHandler(t *testing.T) {
+	t.Run("should return StatusForbidden when the loan limit is reached", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(nil, apperror.ErrLoanLimitReached{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrLoanLimitReached{}.Error()})
+		borrowRequestJSON, _ := json.Marshal(*borrowRequest)
+		body := strings.NewReader(string(borrowRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusConflict when the book is already borrowed by the user", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(nil, apperror.ErrDuplicateLoan{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrDuplicateLoan{}.Error()})
+		borrowRequestJSON, _ := json.Marshal(*borrowRequest)
+		body := strings.NewReader(string(borrowRequestJSON))
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestBatchBorrowHandler(t *testing.T) {
+	t.Run("should return StatusCreated when every book is borrowed", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		batchResponse := &dto.BatchResponse{
+			Mode:      "atomic",
+			Committed: true,
+			Succeeded: 1,
+			Items:     []dto.BatchItemResponse{{BookId: bookId, Status: "succeeded", Data: borrowResponse}},
+		}
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("BatchRecord", ctx, &dto.BatchBorrowRequest{BookIds: []int{bookId}, UserId: 1}).Return(batchResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/batch", middleware.AuthMiddleware, borrowHandler.BatchBorrowHandler)
+		body := strings.NewReader(`{"book_ids":[1],"user_id":2}`)
+		expectedResponse, _ := json.Marshal(gin.H{"data": batchResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/batch", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusMultiStatus when some books fail in best-effort mode", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		batchResponse := &dto.BatchResponse{
+			Mode:      "best_effort",
+			Committed: true,
+			Succeeded: 1,
+			Failed:    1,
+			Items: []dto.BatchItemResponse{
+				{BookId: bookId, Status: "succeeded", Data: borrowResponse},
+				{BookId: 2, Status: "failed", Error: apperror.ErrEmptyStock{}.Error()},
+			},
+		}
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("BatchRecord", ctx, &dto.BatchBorrowRequest{BookIds: []int{bookId, 2}, Mode: "best_effort", UserId: 1}).Return(batchResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/batch", middleware.AuthMiddleware, borrowHandler.BatchBorrowHandler)
+		body := strings.NewReader(`{"book_ids":[1,2],"mode":"best_effort"}`)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/batch", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusMultiStatus, w.Code)
+	})
+
+	t.Run("should return StatusUnprocessableEntity when an atomic batch is rolled back", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		batchResponse := &dto.BatchResponse{
+			Mode:   "atomic",
+			Failed: 1,
+			Items:  []dto.BatchItemResponse{{BookId: bookId, Status: "failed", Error: apperror.ErrEmptyStock{}.Error()}},
+		}
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("BatchRecord", ctx, &dto.BatchBorrowRequest{BookIds: []int{bookId}, Mode: "atomic", UserId: 1}).Return(batchResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/batch", middleware.AuthMiddleware, borrowHandler.BatchBorrowHandler)
+		body := strings.NewReader(`{"book_ids":[1],"mode":"atomic"}`)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/batch", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("should return StatusBadRequest when no book is given", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		borrowHandler := handler.NewBorrowHandler(new(mocks.BorrowUsecase))
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records/batch", middleware.AuthMiddleware, borrowHandler.BatchBorrowHandler)
+		body := strings.NewReader(`{"book_ids":[]}`)
+		fieldErrors := []util.FieldError{{Field: "BookIds", Message: "Should be greater than 0"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records/batch", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestBatchReturnHandler(t *testing.T) {
+	t.Run("should return StatusOK when every record is returned", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		batchResponse := &dto.BatchResponse{
+			Mode:      "atomic",
+			Committed: true,
+			Succeeded: 1,
+			Items:     []dto.BatchItemResponse{{Id: recordId, Status: "succeeded"}},
+		}
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("BatchReturn", ctx, &dto.BatchReturnRequest{Ids: []int{recordId}, UserId: 1}).Return(batchResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/borrowing-records/batch", middleware.AuthMiddleware, borrowHandler.BatchReturnHandler)
+		body := strings.NewReader(`{"ids":[1]}`)
+		expectedResponse, _ := json.Marshal(gin.H{"data": batchResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/borrowing-records/batch", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestScanBarcodeHandler(t *testing.T) {
+	t.Run("should return StatusCreated with the loan of the scanned copy", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		barcodeResponse := *borrowResponse
+		barcodeResponse.CopyId = 2
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{Barcode: "BK000001-002", UserId: 1}).Return(&barcodeResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
+		body := strings.NewReader(`{"barcode":"BK000001-002"}`)
+		expectedResponse, _ := json.Marshal(gin.H{"data": barcodeResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when neither book id nor barcode is given", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		borrowHandler := handler.NewBorrowHandler(new(mocks.BorrowUsecase))
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, borrowHandler.BorrowBookHandler)
+		body := strings.NewReader(`{}`)
+		fieldErrors := []util.FieldError{{Field: "BookId", Message: "Required when barcode is empty"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusNotFound when the barcode is unknown", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Return", ctx, &dto.ReturnRequest{Barcode: "UNKNOWN", UserId: 1}).Return(nil, apperror.ErrCopyNotFound{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/borrowing-records", middleware.AuthMiddleware, borrowHandler.ReturnBookHandler)
+		body := strings.NewReader(`{"barcode":"UNKNOWN"}`)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrCopyNotFound{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/borrowing-records", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestUpdateBorrowStatusHandler(t *testing.T) {
+	t.Run("should return StatusOK with the updated record when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		statusRequest := &dto.BorrowStatusRequest{Status: "lost", ReplacementCharge: 25000}
+		lostResponse := *borrowResponse
+		lostResponse.Status = "lost"
+		lostResponse.FineAmount = 25000
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("UpdateStatus", ctx, recordId, 2, statusRequest).Return(&lostResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/borrowing-records/:id/status", middleware.AuthMiddleware, borrowHandler.UpdateBorrowStatusHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": lostResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/borrowing-records/1/status", strings.NewReader(`{"status":"lost","replacement_charge":25000}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusConflict when the transition is not allowed", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("UpdateStatus", ctx, recordId, 2, &dto.BorrowStatusRequest{Status: "lost"}).Return(nil, apperror.ErrInvalidBorrowTransition{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/borrowing-records/:id/status", middleware.AuthMiddleware, borrowHandler.UpdateBorrowStatusHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrInvalidBorrowTransition{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/borrowing-records/1/status", strings.NewReader(`{"status":"lost"}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the status is unknown", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/borrowing-records/:id/status", middleware.AuthMiddleware, borrowHandler.UpdateBorrowStatusHandler)
+		fieldErrors := []util.FieldError{{Field: "Status", Message: "Should be one of: returned lost damaged_on_return claimed_returned"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/borrowing-records/1/status", strings.NewReader(`{"status":"borrowed"}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+		mockBorrowUsecase.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// leasedIdempotencyStore keeps a single key in memory, like the idempotency_keys table: a reservation
+// takes the key over when leaseExpired is set, and only the current reservation can save or release it.
+type leasedIdempotencyStore struct {
+	key          *entity.IdempotencyKey
+	reservations int64
+	leaseExpired bool
+}
+
+func (s *leasedIdempotencyStore) ReserveKey(ctx context.Context, key *entity.IdempotencyKey, ttl time.Duration, lease time.Duration) (int64, error) {
+	if s.key != nil && !(s.key.StatusCode == 0 && s.leaseExpired) {
+		return 0, nil
+	}
+
+	s.reservations++
+	reserved := *key
+	reserved.Reservation = s.reservations
+	s.key = &reserved
+	s.leaseExpired = false
+	return s.reservations, nil
+}
+
+func (s *leasedIdempotencyStore) GetKey(ctx context.Context, userId int, key string) (*entity.IdempotencyKey, error) {
+	if s.key == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	stored := *s.key
+	return &stored, nil
+}
+
+func (s *leasedIdempotencyStore) SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error {
+	if s.key != nil && s.key.Reservation == key.Reservation {
+		s.key.StatusCode = key.StatusCode
+		s.key.ResponseBody = key.ResponseBody
+	}
+	return nil
+}
+
+func (s *leasedIdempotencyStore) ReleaseKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	if s.key != nil && s.key.Reservation == key.Reservation {
+		s.key = nil
+	}
+	return nil
+}
+
+func TestIdempotentBorrowHandler(t *testing.T) {
+	t.Run("should store the response of the first request sent with an idempotency key", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockIdempotencyRepo := new(mocks.IdempotencyRepo)
+		mockIdempotencyRepo.On("ReserveKey", ctx, mock.Anything, time.Hour, time.Minute).Return(int64(1), nil)
+		mockIdempotencyRepo.On("SaveResponse", ctx, mock.Anything).Return(nil)
+		middleware.SetIdempotencyStore(mockIdempotencyRepo, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, borrowHandler.BorrowBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": borrowResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		ctx.Request.Header.Set("Idempotency-Key", "borrow-1")
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockIdempotencyRepo.AssertCalled(t, "SaveResponse", ctx, mock.MatchedBy(func(key *entity.IdempotencyKey) bool {
+			return key.UserId == 1 && key.Key == "borrow-1" && key.Reservation == 1 && key.StatusCode == http.StatusCreated && string(key.ResponseBody) == string(expectedResponse)
+		}))
+	})
+
+	t.Run("should replay the stored response when the request is retried", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		storedResponse, _ := json.Marshal(gin.H{"data": borrowResponse})
+		mockIdempotencyRepo := new(mocks.IdempotencyRepo)
+		mockIdempotencyRepo.On("ReserveKey", ctx, mock.Anything, time.Hour, time.Minute).Return(int64(0), nil)
+		fingerprint := sha256.Sum256([]byte("POST /borrowing-records\n" + `{"book_id":1}`))
+		mockIdempotencyRepo.On("GetKey", ctx, 1, "borrow-1").Return(&entity.IdempotencyKey{
+			Fingerprint:  hex.EncodeToString(fingerprint[:]),
+			StatusCode:   http.StatusCreated,
+			ResponseBody: storedResponse,
+		}, nil)
+		middleware.SetIdempotencyStore(mockIdempotencyRepo, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, borrowHandler.BorrowBookHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		ctx.Request.Header.Set("Idempotency-Key", "borrow-1")
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(storedResponse), w.Body.String())
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+		mockBorrowUsecase.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reserve the key again when it is released while being looked up", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockIdempotencyRepo := new(mocks.IdempotencyRepo)
+		mockIdempotencyRepo.On("ReserveKey", ctx, mock.Anything, time.Hour, time.Minute).Return(int64(0), nil).Once()
+		mockIdempotencyRepo.On("GetKey", ctx, 1, "borrow-1").Return(nil, sql.ErrNoRows).Once()
+		mockIdempotencyRepo.On("ReserveKey", ctx, mock.Anything, time.Hour, time.Minute).Return(int64(1), nil).Once()
+		mockIdempotencyRepo.On("SaveResponse", ctx, mock.Anything).Return(nil)
+		middleware.SetIdempotencyStore(mockIdempotencyRepo, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, borrowHandler.BorrowBookHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		ctx.Request.Header.Set("Idempotency-Key", "borrow-1")
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockIdempotencyRepo.AssertNumberOfCalls(t, "ReserveKey", 2)
+		mockBorrowUsecase.AssertCalled(t, "Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1})
+	})
+
+	t.Run("should return StatusUnprocessableEntity when the key is reused for a different request", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockIdempotencyRepo := new(mocks.IdempotencyRepo)
+		mockIdempotencyRepo.On("ReserveKey", ctx, mock.Anything, time.Hour, time.Minute).Return(int64(0), nil)
+		mockIdempotencyRepo.On("GetKey", ctx, 1, "borrow-1").Return(&entity.IdempotencyKey{Fingerprint: "another request", StatusCode: http.StatusCreated}, nil)
+		middleware.SetIdempotencyStore(mockIdempotencyRepo, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, borrowHandler.BorrowBookHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrIdempotencyKeyReused{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":2}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		ctx.Request.Header.Set("Idempotency-Key", "borrow-1")
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+		mockBorrowUsecase.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should keep the response of the retry that took the key over when the first request finishes late", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		store := &leasedIdempotencyStore{}
+		middleware.SetIdempotencyStore(store, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, handler.NewBorrowHandler(mockBorrowUsecase).BorrowBookHandler)
+		send := func() *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			request, _ := http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1}`))
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			request.Header.Set("Idempotency-Key", "borrow-1")
+			router.ServeHTTP(w, request)
+			return w
+		}
+		slowResponse := *borrowResponse
+		slowResponse.Id = 2
+		var retry *httptest.ResponseRecorder
+		mockBorrowUsecase.On("Record", mock.Anything, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(&slowResponse, nil).Once().Run(func(args mock.Arguments) {
+			// the first request outlives its lease, and the retry takes the key over meanwhile
+			store.leaseExpired = true
+			retry = send()
+		})
+		mockBorrowUsecase.On("Record", mock.Anything, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil).Once()
+		retryResponse, _ := json.Marshal(gin.H{"data": borrowResponse})
+
+		first := send()
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, int64(2), store.key.Reservation)
+		assert.Equal(t, string(retryResponse), string(store.key.ResponseBody))
+	})
+
+	t.Run("should not release the key of the retry that took it over when the first request fails late", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		store := &leasedIdempotencyStore{}
+		middleware.SetIdempotencyStore(store, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		router := gin.New()
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, handler.NewBorrowHandler(mockBorrowUsecase).BorrowBookHandler)
+		send := func() *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			request, _ := http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1}`))
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			request.Header.Set("Idempotency-Key", "borrow-1")
+			router.ServeHTTP(w, request)
+			return w
+		}
+		mockBorrowUsecase.On("Record", mock.Anything, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(nil, apperror.ErrEmptyStock{}).Once().Run(func(args mock.Arguments) {
+			store.leaseExpired = true
+			send()
+		})
+		mockBorrowUsecase.On("Record", mock.Anything, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(borrowResponse, nil).Once()
+
+		first := send()
+
+		assert.Equal(t, http.StatusBadRequest, first.Code)
+		assert.NotNil(t, store.key)
+		assert.Equal(t, int64(2), store.key.Reservation)
+		assert.Equal(t, http.StatusCreated, store.key.StatusCode)
+	})
+
+	t.Run("should release the key when the request fails", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockIdempotencyRepo := new(mocks.IdempotencyRepo)
+		mockIdempotencyRepo.On("ReserveKey", ctx, mock.Anything, time.Hour, time.Minute).Return(int64(1), nil)
+		mockIdempotencyRepo.On("ReleaseKey", ctx, mock.Anything).Return(nil)
+		middleware.SetIdempotencyStore(mockIdempotencyRepo, time.Hour, time.Minute)
+		defer middleware.SetIdempotencyStore(nil, 0, 0)
+		mockBorrowUsecase := new(mocks.BorrowUsecase)
+		mockBorrowUsecase.On("Record", ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 1}).Return(nil, apperror.ErrEmptyStock{})
+		borrowHandler := handler.NewBorrowHandler(mockBorrowUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/borrowing-records", middleware.AuthMiddleware, middleware.IdempotencyMiddleware, borrowHandler.BorrowBookHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/borrowing-records", strings.NewReader(`{"book_id":1}`))
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		ctx.Request.Header.Set("Idempotency-Key", "borrow-1")
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockIdempotencyRepo.AssertCalled(t, "ReleaseKey", ctx, mock.MatchedBy(func(key *entity.IdempotencyKey) bool {
+			return key.UserId == 1 && key.Key == "borrow-1" && key.Reservation == 1
+		}))
+		mockIdempotencyRepo.AssertNotCalled(t, "SaveResponse", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CopyHandler struct {
+	usecase usecase.CopyUsecase
+}
+
+func NewCopyHandler(uc usecase.CopyUsecase) CopyHandler {
+	return CopyHandler{
+		usecase: uc,
+	}
+}
+
+func (h CopyHandler) GetBookCopiesHandler(ctx *gin.Context) {
+	bookId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	copies, err := h.usecase.ListBookCopies(ctx, bookId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": copies})
+}
+
+func (h CopyHandler) AddCopyHandler(ctx *gin.Context) {
+	bookId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var copyRequest dto.CopyRequest
+	err = ctx.ShouldBindJSON(&copyRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	copyResponse, err := h.usecase.AddCopy(ctx, bookId, &copyRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": copyResponse})
+}
+
+func (h CopyHandler) PatchCopyHandler(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var copyPatchRequest dto.CopyPatchRequest
+	err = ctx.ShouldBindJSON(&copyPatchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	copyResponse, err := h.usecase.PatchCopy(ctx, id, &copyPatchRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": copyResponse})
+}
@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/handler"
+	"archive_lib/middleware"
+	"archive_lib/mocks"
+	"archive_lib/util"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBookCopiesHandler(t *testing.T) {
+	t.Run("should return StatusOK with the copies of the book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		copies := []dto.CopyResponse{{Id: 1, BookId: 1, Barcode: "BK000001-001", Condition: "good", Status: "available"}}
+		mockCopyUsecase := new(mocks.CopyUsecase)
+		mockCopyUsecase.On("ListBookCopies", ctx, 1).Return(copies, nil)
+		copyHandler := handler.NewCopyHandler(mockCopyUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/books/:id/copies", copyHandler.GetBookCopiesHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": copies})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/books/1/copies", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestAddCopyHandler(t *testing.T) {
+	t.Run("should return StatusCreated with the new copy when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		copyRequest := &dto.CopyRequest{Barcode: "BK000001-003", Condition: "new", Location: "A1"}
+		copyResponse := &dto.CopyResponse{Id: 3, BookId: 1, Barcode: "BK000001-003", Condition: "new", Location: "A1", Status: "available"}
+		mockCopyUsecase := new(mocks.CopyUsecase)
+		mockCopyUsecase.On("AddCopy", ctx, 1, copyRequest).Return(copyResponse, nil)
+		copyHandler := handler.NewCopyHandler(mockCopyUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books/:id/copies", copyHandler.AddCopyHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": copyResponse})
+		copyRequestJSON, _ := json.Marshal(*copyRequest)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/copies", strings.NewReader(string(copyRequestJSON)))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the barcode is already used", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		copyRequest := &dto.CopyRequest{Barcode: "BK000001-001"}
+		mockCopyUsecase := new(mocks.CopyUsecase)
+		mockCopyUsecase.On("AddCopy", ctx, 1, copyRequest).Return(nil, apperror.ErrDuplicateBarcode{})
+		copyHandler := handler.NewCopyHandler(mockCopyUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books/:id/copies", copyHandler.AddCopyHandler)
+		fieldErrors := []util.FieldError{{Field: "barcode", Message: apperror.ErrDuplicateBarcode{}.Error()}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+		copyRequestJSON, _ := json.Marshal(*copyRequest)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/copies", strings.NewReader(string(copyRequestJSON)))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the condition is unknown", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		copyHandler := handler.NewCopyHandler(new(mocks.CopyUsecase))
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books/:id/copies", copyHandler.AddCopyHandler)
+		fieldErrors := []util.FieldError{{Field: "Condition", Message: "Should be one of: new good fair poor"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/copies", strings.NewReader(`{"barcode":"BK000001-003","condition":"torn"}`))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestPatchCopyHandler(t *testing.T) {
+	t.Run("should return StatusNotFound when the copy does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		location := "B2"
+		mockCopyUsecase := new(mocks.CopyUsecase)
+		mockCopyUsecase.On("PatchCopy", ctx, 9, &dto.CopyPatchRequest{Location: &location}).Return(nil, apperror.ErrCopyNotFound{})
+		copyHandler := handler.NewCopyHandler(mockCopyUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.PATCH("/copies/:id", copyHandler.PatchCopyHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrCopyNotFound{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPatch, "/copies/9", strings.NewReader(`{"location":"B2"}`))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/usecase"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FineHandler struct {
+	usecase usecase.FineUsecase
+}
+
+func NewFineHandler(uc usecase.FineUsecase) FineHandler {
+	return FineHandler{
+		usecase: uc,
+	}
+}
+
+func (h FineHandler) GetMyFinesHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	finesResponse, err := h.usecase.GetUserFines(ctx, userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": finesResponse})
+}
+
+func (h FineHandler) GetUserFinesHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	finesResponse, err := h.usecase.GetUserFines(ctx, userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": finesResponse})
+}
+
+func (h FineHandler) RecordPaymentHandler(ctx *gin.Context) {
+	h.settle(ctx, h.usecase.RecordPayment)
+}
+
+func (h FineHandler) WaiveFineHandler(ctx *gin.Context) {
+	h.settle(ctx, h.usecase.WaiveFine)
+}
+
+func (h FineHandler) settle(ctx *gin.Context, settleFn func(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error)) {
+	staffId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	var request dto.FineSettlementRequest
+	err = ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	entryResponse, err := settleFn(ctx, userId, staffId, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": entryResponse})
+}
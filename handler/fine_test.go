@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/handler"
+	"archive_lib/middleware"
+	"archive_lib/mocks"
+	"archive_lib/util"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMyFinesHandler(t *testing.T) {
+	t.Run("should return StatusOK with the fine balance of the user", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		borrowId := 1
+		finesResponse := &dto.FinesResponse{
+			Balance: 150,
+			Entries: []dto.FineEntryResponse{{Id: 1, BorrowId: &borrowId, Kind: "charge", Amount: 150, Note: "Returned 3 day(s) late", CreatedAt: time.Now()}},
+		}
+		mockFineUsecase := new(mocks.FineUsecase)
+		mockFineUsecase.On("GetUserFines", ctx, 2).Return(finesResponse, nil)
+		fineHandler := handler.NewFineHandler(mockFineUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/me/fines", middleware.AuthMiddleware, fineHandler.GetMyFinesHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": finesResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/me/fines", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestRecordPaymentHandler(t *testing.T) {
+	t.Run("should return StatusCreated with the payment entry when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		settlement := &dto.FineSettlementRequest{Amount: 100, Note: "cash"}
+		entryResponse := &dto.FineEntryResponse{Id: 2, Kind: "payment", Amount: 100, Note: "cash", CreatedAt: time.Now()}
+		mockFineUsecase := new(mocks.FineUsecase)
+		mockFineUsecase.On("RecordPayment", ctx, 2, 1, settlement).Return(entryResponse, nil)
+		fineHandler := handler.NewFineHandler(mockFineUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/users/:id/fines/payments", middleware.AuthMiddleware, fineHandler.RecordPaymentHandler)
+		settlementJSON, _ := json.Marshal(settlement)
+		body := strings.NewReader(string(settlementJSON))
+		expectedResponse, _ := json.Marshal(gin.H{"data": entryResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/users/2/fines/payments", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the amount is not positive", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		fineHandler := handler.NewFineHandler(new(mocks.FineUsecase))
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/users/:id/fines/payments", middleware.AuthMiddleware, fineHandler.RecordPaymentHandler)
+		body := strings.NewReader(`{"amount":-5}`)
+		fieldErrors := []util.FieldError{{Field: "Amount", Message: "Should be greater than 0"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/users/2/fines/payments", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestWaiveFineHandler(t *testing.T) {
+	t.Run("should return StatusBadRequest when waiving more than the balance", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "librarian")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		settlement := &dto.FineSettlementRequest{Amount: 500}
+		mockFineUsecase := new(mocks.FineUsecase)
+		mockFineUsecase.On("WaiveFine", ctx, 2, 1, settlement).Return(nil, apperror.ErrAmountExceedsBalance{})
+		fineHandler := handler.NewFineHandler(mockFineUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/users/:id/fines/waivers", middleware.AuthMiddleware, fineHandler.WaiveFineHandler)
+		settlementJSON, _ := json.Marshal(settlement)
+		body := strings.NewReader(string(settlementJSON))
+		fieldErrors := []util.FieldError{{Field: "amount", Message: apperror.ErrAmountExceedsBalance{}.Error()}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/users/2/fines/waivers", body)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
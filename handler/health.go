@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"archive_lib/entity"
+	"archive_lib/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	usecase usecase.HealthUsecase
+}
+
+func NewHealthHandler(uc usecase.HealthUsecase) HealthHandler {
+	return HealthHandler{
+		usecase: uc,
+	}
+}
+
+// LivenessHandler only tells that the process is serving requests.
+func (h HealthHandler) LivenessHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"data": gin.H{"status": entity.HealthUp}})
+}
+
+func (h HealthHandler) ReadinessHandler(ctx *gin.Context) {
+	err := h.usecase.Ready(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": gin.H{"status": entity.HealthUp}})
+}
+
+func (h HealthHandler) StatusHandler(ctx *gin.Context) {
+	statusResponse := h.usecase.Status(ctx)
+
+	code := http.StatusOK
+	if statusResponse.Status != entity.HealthUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(code, gin.H{"data": statusResponse})
+}
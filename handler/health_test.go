@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/handler"
+	"archive_lib/middleware"
+	"archive_lib/mocks"
+	"archive_lib/util"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	t.Run("should return StatusOK when ready", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockHealthUsecase := new(mocks.HealthUsecase)
+		mockHealthUsecase.On("Ready", ctx).Return(nil)
+		healthHandler := handler.NewHealthHandler(mockHealthUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/readyz", healthHandler.ReadinessHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": gin.H{"status": entity.HealthUp}})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusServiceUnavailable when shutting down", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockHealthUsecase := new(mocks.HealthUsecase)
+		mockHealthUsecase.On("Ready", ctx).Return(apperror.ErrShuttingDown{})
+		healthHandler := handler.NewHealthHandler(mockHealthUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/readyz", healthHandler.ReadinessHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrShuttingDown{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestStatusHandler(t *testing.T) {
+	t.Run("should return StatusServiceUnavailable with the components when one is down", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		statusResponse := &dto.StatusResponse{
+			Status: entity.HealthDown,
+			Uptime: "1m0s",
+			Build:  dto.BuildInfo{Version: "dev", GoVersion: "go1.19"},
+			Components: []dto.ComponentStatus{
+				{Name: "database", Status: entity.HealthDown, Error: "connection refused"},
+			},
+		}
+		mockHealthUsecase := new(mocks.HealthUsecase)
+		mockHealthUsecase.On("Status", ctx).Return(statusResponse)
+		healthHandler := handler.NewHealthHandler(mockHealthUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/status", healthHandler.StatusHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": statusResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/status", nil)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusForbidden when a member asks for the status", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockHealthUsecase := new(mocks.HealthUsecase)
+		healthHandler := handler.NewHealthHandler(mockHealthUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/status", middleware.AuthMiddleware, middleware.RequireRole(entity.RoleLibrarian), healthHandler.StatusHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/status", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockHealthUsecase.AssertNotCalled(t, "Status", mock.Anything)
+	})
+}
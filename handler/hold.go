@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HoldHandler struct {
+	usecase usecase.HoldUsecase
+}
+
+func NewHoldHandler(uc usecase.HoldUsecase) HoldHandler {
+	return HoldHandler{
+		usecase: uc,
+	}
+}
+
+func (h HoldHandler) PlaceHoldHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	bookId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	holdResponse, err := h.usecase.PlaceHold(ctx, bookId, userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": holdResponse})
+}
+
+func (h HoldHandler) GetMyHoldsHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	holds, err := h.usecase.ListUserHolds(ctx, userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": holds})
+}
+
+func (h HoldHandler) GetBookHoldsHandler(ctx *gin.Context) {
+	bookId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	holds, err := h.usecase.ListBookHolds(ctx, bookId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": holds})
+}
+
+func (h HoldHandler) CancelHoldHandler(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.ErrInvalidId{})
+		return
+	}
+
+	err = h.usecase.CancelHold(ctx, id, userId)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
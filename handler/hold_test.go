@@ -0,0 +1,121 @@
+package handler_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/handler"
+	"archive_lib/middleware"
+	"archive_lib/mocks"
+	"archive_lib/util"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaceHoldHandler(t *testing.T) {
+	t.Run("should return StatusCreated with the hold when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		holdResponse := &dto.HoldResponse{Id: 1, BookId: 1, UserId: 2, Status: "waiting", CreatedAt: time.Now()}
+		mockHoldUsecase := new(mocks.HoldUsecase)
+		mockHoldUsecase.On("PlaceHold", ctx, 1, 2).Return(holdResponse, nil)
+		holdHandler := handler.NewHoldHandler(mockHoldUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books/:id/holds", middleware.AuthMiddleware, holdHandler.PlaceHoldHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": holdResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/holds", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when the book is in stock", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockHoldUsecase := new(mocks.HoldUsecase)
+		mockHoldUsecase.On("PlaceHold", ctx, 1, 2).Return(nil, apperror.ErrStockAvailable{})
+		holdHandler := handler.NewHoldHandler(mockHoldUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/books/:id/holds", middleware.AuthMiddleware, holdHandler.PlaceHoldHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrStockAvailable{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/books/1/holds", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestGetMyHoldsHandler(t *testing.T) {
+	t.Run("should return StatusOK with the holds of the user", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		expiresAt := time.Now().Add(72 * time.Hour)
+		holds := []dto.HoldResponse{{Id: 1, BookId: 1, UserId: 2, Status: "ready", CreatedAt: time.Now(), ExpiresAt: &expiresAt}}
+		mockHoldUsecase := new(mocks.HoldUsecase)
+		mockHoldUsecase.On("ListUserHolds", ctx, 2).Return(holds, nil)
+		holdHandler := handler.NewHoldHandler(mockHoldUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.GET("/me/holds", middleware.AuthMiddleware, holdHandler.GetMyHoldsHandler)
+		expectedResponse, _ := json.Marshal(gin.H{"data": holds})
+
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/me/holds", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestCancelHoldHandler(t *testing.T) {
+	t.Run("should return StatusNoContent when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockHoldUsecase := new(mocks.HoldUsecase)
+		mockHoldUsecase.On("CancelHold", ctx, 1, 2).Return(nil)
+		holdHandler := handler.NewHoldHandler(mockHoldUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.DELETE("/holds/:id", middleware.AuthMiddleware, holdHandler.CancelHoldHandler)
+
+		ctx.Request, _ = http.NewRequest(http.MethodDelete, "/holds/1", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return StatusBadRequest when id is not a number", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("2", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockHoldUsecase := new(mocks.HoldUsecase)
+		holdHandler := handler.NewHoldHandler(mockHoldUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.DELETE("/holds/:id", middleware.AuthMiddleware, holdHandler.CancelHoldHandler)
+		fieldErrors := []util.FieldError{{Field: "id", Message: apperror.ErrInvalidId{}.Error()}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodDelete, "/holds/abc", nil)
+		ctx.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
@@ -0,0 +1,20 @@
+package handler_test
+
+import (
+	"archive_lib/middleware"
+	"archive_lib/util"
+	"os"
+	"testing"
+	"time"
+)
+
+var testJWTConfig = util.JWTConfig{
+	Secret:         "jwt secret for test",
+	Issuer:         "archive_lib",
+	AccessTokenTTL: 15 * time.Minute,
+}
+
+func TestMain(m *testing.M) {
+	middleware.SetJWTConfig(testJWTConfig)
+	os.Exit(m.Run())
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"archive_lib/dto"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func buildPageLink(ctx *gin.Context, params map[string]string) string {
+	values := ctx.Request.URL.Query()
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	link := url.URL{Path: ctx.Request.URL.Path, RawQuery: values.Encode()}
+	return link.String()
+}
+
+func buildPageLinks(ctx *gin.Context, pagination *dto.PaginationResponse) dto.PaginationLinks {
+	links := dto.PaginationLinks{Self: buildPageLink(ctx, nil)}
+
+	if pagination.PerPage == 0 {
+		if pagination.NextCursor != "" {
+			links.Next = buildPageLink(ctx, map[string]string{"cursor": pagination.NextCursor})
+		}
+		return links
+	}
+
+	perPage := strconv.Itoa(pagination.PerPage)
+	lastPage := (pagination.Total + pagination.PerPage - 1) / pagination.PerPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	links.First = buildPageLink(ctx, map[string]string{"page": "1", "per_page": perPage})
+	links.Last = buildPageLink(ctx, map[string]string{"page": strconv.Itoa(lastPage), "per_page": perPage})
+	if pagination.Page > 1 {
+		links.Prev = buildPageLink(ctx, map[string]string{"page": strconv.Itoa(pagination.Page - 1), "per_page": perPage})
+	}
+	if pagination.Page < lastPage {
+		links.Next = buildPageLink(ctx, map[string]string{"page": strconv.Itoa(pagination.Page + 1), "per_page": perPage})
+	}
+
+	return links
+}
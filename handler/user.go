@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"archive_lib/apperror"
 	"archive_lib/dto"
 	"archive_lib/usecase"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -34,3 +38,64 @@ func (h UserHandler) Login(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"data": accessToken})
 }
+
+func (h UserHandler) Register(ctx *gin.Context) {
+	var registerRequest dto.RegisterRequest
+	err := ctx.ShouldBindJSON(&registerRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	userResponse, err := h.usecase.Register(ctx, &registerRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": userResponse})
+}
+
+func (h UserHandler) Refresh(ctx *gin.Context) {
+	var refreshRequest dto.RefreshRequest
+	err := ctx.ShouldBindJSON(&refreshRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	authResponse, err := h.usecase.Refresh(ctx, &refreshRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": authResponse})
+}
+
+func (h UserHandler) Logout(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		return
+	}
+
+	var logoutRequest dto.LogoutRequest
+	err = ctx.ShouldBindJSON(&logoutRequest)
+	if err != nil && !errors.Is(err, io.EOF) {
+		ctx.Error(err)
+		return
+	}
+
+	logoutRequest.UserId = userId
+	logoutRequest.TokenId = ctx.GetString("jti")
+	logoutRequest.ExpiresAt = ctx.GetTime("expires_at")
+
+	err = h.usecase.Logout(ctx, &logoutRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
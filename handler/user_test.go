@@ -15,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -26,9 +27,8 @@ var (
 
 func TestLoginHandler(t *testing.T) {
 	t.Run("should return StatusOK with access token when no error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
-		jwtImpl := util.NewJWT()
-		token, _ := jwtImpl.GenerateJWT("1")
+		jwtImpl := util.NewJWT(testJWTConfig)
+		token, _ := jwtImpl.GenerateJWT("1", "member")
 		authResponse := dto.AuthResponse{AccessToken: token}
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
@@ -67,7 +67,6 @@ func TestLoginHandler(t *testing.T) {
 	})
 
 	t.Run("should return error when login encounters error", func(t *testing.T) {
-		t.Setenv("JWT_SECRET", "jwt secret for test")
 		w := httptest.NewRecorder()
 		ctx, router := gin.CreateTestContext(w)
 		mockUserUsecase := new(mocks.UserUsecase)
@@ -84,3 +83,174 @@ func TestLoginHandler(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
+
+func TestRegisterHandler(t *testing.T) {
+	registerRequest := &dto.RegisterRequest{
+		Username: "dokja",
+		Email:    "dokja@mail.com",
+		Password: "benchmark",
+	}
+
+	t.Run("should return StatusCreated with the registered user when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		userResponse := &dto.UserResponse{Id: 1, Username: "dokja", Email: "dokja@mail.com"}
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserUsecase.On("Register", ctx, registerRequest).Return(userResponse, nil)
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.POST("/register", userHandler.Register)
+		registerRequestJSON, _ := json.Marshal(*registerRequest)
+		body := strings.NewReader(string(registerRequestJSON))
+		expectedResponse, _ := json.Marshal(gin.H{"data": userResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/register", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when registering with short password", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockUserUsecase := new(mocks.UserUsecase)
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/register", userHandler.Register)
+		invalidRegisterRequest := *registerRequest
+		invalidRegisterRequest.Password = "short"
+		registerRequestJSON, _ := json.Marshal(invalidRegisterRequest)
+		body := strings.NewReader(string(registerRequestJSON))
+		fieldErrors := []util.FieldError{{Field: "Password", Message: "Should be at least 8 characters"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/register", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when registering with existing email", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserUsecase.On("Register", ctx, registerRequest).Return(nil, apperror.ErrDuplicateEmail{})
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/register", userHandler.Register)
+		registerRequestJSON, _ := json.Marshal(*registerRequest)
+		body := strings.NewReader(string(registerRequestJSON))
+		fieldErrors := []util.FieldError{{Field: "email", Message: "Email already registered"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/register", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestRefreshHandler(t *testing.T) {
+	refreshRequest := &dto.RefreshRequest{RefreshToken: "refresh-token"}
+
+	t.Run("should return StatusOK with new tokens when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		authResponse := &dto.AuthResponse{AccessToken: "access-token", RefreshToken: "new-refresh-token", ExpiresIn: 900}
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserUsecase.On("Refresh", ctx, refreshRequest).Return(authResponse, nil)
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.POST("/token/refresh", userHandler.Refresh)
+		refreshRequestJSON, _ := json.Marshal(*refreshRequest)
+		body := strings.NewReader(string(refreshRequestJSON))
+		expectedResponse, _ := json.Marshal(gin.H{"data": authResponse})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/token/refresh", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusUnauthorized when a rotated refresh token is reused", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserUsecase.On("Refresh", ctx, refreshRequest).Return(nil, apperror.ErrRefreshTokenReused{})
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/token/refresh", userHandler.Refresh)
+		refreshRequestJSON, _ := json.Marshal(*refreshRequest)
+		body := strings.NewReader(string(refreshRequestJSON))
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrRefreshTokenReused{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/token/refresh", body)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+
+	t.Run("should return StatusBadRequest when refresh token is missing", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockUserUsecase := new(mocks.UserUsecase)
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/token/refresh", userHandler.Refresh)
+		fieldErrors := []util.FieldError{{Field: "RefreshToken", Message: "Required"}}
+		expectedResponse, _ := json.Marshal(gin.H{"message": fieldErrors})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/token/refresh", strings.NewReader("{}"))
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
+
+func TestLogoutHandler(t *testing.T) {
+	t.Run("should return StatusNoContent when no error", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserUsecase.On("Logout", ctx, mock.MatchedBy(func(logoutRequest *dto.LogoutRequest) bool {
+			return logoutRequest.UserId == 1 && logoutRequest.TokenId != "" && logoutRequest.RefreshToken == "refresh-token"
+		})).Return(nil)
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/logout", middleware.AuthMiddleware, userHandler.Logout)
+		body := strings.NewReader(`{"refresh_token":"refresh-token"}`)
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/logout", body)
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("should return StatusUnauthorized when the access token has been revoked", func(t *testing.T) {
+		token, _ := util.NewJWT(testJWTConfig).GenerateJWT("1", "member")
+		w := httptest.NewRecorder()
+		ctx, router := gin.CreateTestContext(w)
+		mockTokenRepo := new(mocks.TokenRepo)
+		mockTokenRepo.On("IsAccessTokenRevoked", ctx, mock.AnythingOfType("string")).Return(true, nil)
+		middleware.SetTokenRevocationChecker(mockTokenRepo)
+		t.Cleanup(func() { middleware.SetTokenRevocationChecker(nil) })
+		mockUserUsecase := new(mocks.UserUsecase)
+		userHandler := handler.NewUserHandler(mockUserUsecase)
+		router.Use(middleware.ErrorMiddleware)
+		router.POST("/logout", middleware.AuthMiddleware, userHandler.Logout)
+		expectedResponse, _ := json.Marshal(gin.H{"message": apperror.ErrTokenRevoked{}.Error()})
+
+		ctx.Request, _ = http.NewRequest(http.MethodPost, "/logout", nil)
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		router.HandleContext(ctx)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Equal(t, string(expectedResponse), w.Body.String())
+	})
+}
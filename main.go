@@ -7,6 +7,11 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		api.Migrate(os.Args[2:])
+		return
+	}
+
 	log.Println("PID:", os.Getpid())	
 	api.Run()
 }
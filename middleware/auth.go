@@ -2,19 +2,40 @@ package middleware
 
 import (
 	"archive_lib/apperror"
-	"os"
+	"archive_lib/util"
+	"context"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+type TokenRevocationChecker interface {
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+var (
+	revocationChecker TokenRevocationChecker
+	jwtConfig         util.JWTConfig
+)
+
+// SetJWTConfig sets the secret and issuer AuthMiddleware verifies access tokens with.
+func SetJWTConfig(config util.JWTConfig) {
+	jwtConfig = config
+}
+
+// SetTokenRevocationChecker enables the jti revocation check of AuthMiddleware.
+func SetTokenRevocationChecker(checker TokenRevocationChecker) {
+	revocationChecker = checker
+}
+
 func AuthMiddleware(ctx *gin.Context) {
 	header := ctx.GetHeader("Authorization")
 	headerSection := strings.Split(header, " ")
 
 	if len(headerSection) != 2 {
 		ctx.Error(apperror.ErrLoginFailed{})
+		ctx.Abort()
 		return
 	}
 
@@ -22,31 +43,82 @@ func AuthMiddleware(ctx *gin.Context) {
 	token, err := jwt.Parse(
 		tokenString,
 		func(t *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
+			return []byte(jwtConfig.Secret), nil
 		},
 		jwt.WithIssuedAt(),
-		jwt.WithIssuer("archive_lib"),
+		jwt.WithIssuer(jwtConfig.Issuer),
 		jwt.WithExpirationRequired(),
 		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
 	)
 	if err != nil {
 		ctx.Error(apperror.ErrInvalidToken{})
+		ctx.Abort()
 		return
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
 		ctx.Error(apperror.ErrGetClaimsFailed{})
+		ctx.Abort()
 		return
 	}
 
 	subject, err := claims.GetSubject()
 	if err != nil {
 		ctx.Error(apperror.ErrGetClaimsFailed{})
+		ctx.Abort()
+		return
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		ctx.Error(apperror.ErrInvalidToken{})
+		ctx.Abort()
+		return
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil {
+		ctx.Error(apperror.ErrGetClaimsFailed{})
+		ctx.Abort()
 		return
 	}
 
+	if revocationChecker != nil {
+		revoked, err := revocationChecker.IsAccessTokenRevoked(ctx, jti)
+		if err != nil {
+			ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+		if revoked {
+			ctx.Error(apperror.ErrTokenRevoked{})
+			ctx.Abort()
+			return
+		}
+	}
+
+	role, _ := claims["role"].(string)
+
 	ctx.Set("subject", subject)
+	ctx.Set("role", role)
+	ctx.Set("jti", jti)
+	ctx.Set("expires_at", expiresAt.Time)
 
 	ctx.Next()
 }
+
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		role := ctx.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				ctx.Next()
+				return
+			}
+		}
+
+		ctx.Error(apperror.ErrForbidden{})
+		ctx.Abort()
+	}
+}
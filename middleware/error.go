@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -40,6 +42,26 @@ func ErrorMiddleware(ctx *gin.Context) {
 			return
 		}
 
+		var ne *strconv.NumError
+		if errors.As(err, &ne) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "",
+				Message: "Mismatch data type or malformed request",
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var te *time.ParseError
+		if errors.As(err, &te) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "",
+				Message: "Should be a date in YYYY-MM-DD format",
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
 		var errAuthorNotFound apperror.ErrAuthorNotFound
 		if errors.As(err, &errAuthorNotFound) {
 			fieldErrors = append(fieldErrors, util.FieldError{
@@ -76,6 +98,16 @@ func ErrorMiddleware(ctx *gin.Context) {
 			return
 		}
 
+		var errDuplicateEmail apperror.ErrDuplicateEmail
+		if errors.As(err, &errDuplicateEmail) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "email",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
 		var errWrongPassword apperror.ErrWrongPassword
 		if errors.As(err, &errWrongPassword) {
 			fieldErrors = append(fieldErrors, util.FieldError{
@@ -98,12 +130,30 @@ func ErrorMiddleware(ctx *gin.Context) {
 			return
 		}
 
+		var errTokenRevoked apperror.ErrTokenRevoked
+		if errors.As(err, &errTokenRevoked) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errRefreshTokenReused apperror.ErrRefreshTokenReused
+		if errors.As(err, &errRefreshTokenReused) {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+			return
+		}
+
 		var errLoginFailed apperror.ErrLoginFailed
 		if errors.As(err, &errLoginFailed) {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
 			return
 		}
 
+		var errForbidden apperror.ErrForbidden
+		if errors.As(err, &errForbidden) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+
 		var errEmptyStock apperror.ErrEmptyStock
 		if errors.As(err, &errEmptyStock) {
 			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
@@ -134,6 +184,270 @@ func ErrorMiddleware(ctx *gin.Context) {
 			return
 		}
 
+		var errLoanNotRenewable apperror.ErrLoanNotRenewable
+		if errors.As(err, &errLoanNotRenewable) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errRenewalLimitReached apperror.ErrRenewalLimitReached
+		if errors.As(err, &errRenewalLimitReached) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errBookHasHolds apperror.ErrBookHasHolds
+		if errors.As(err, &errBookHasHolds) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errStockAvailable apperror.ErrStockAvailable
+		if errors.As(err, &errStockAvailable) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errDuplicateHold apperror.ErrDuplicateHold
+		if errors.As(err, &errDuplicateHold) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errHoldNotFound apperror.ErrHoldNotFound
+		if errors.As(err, &errHoldNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errHoldNotActive apperror.ErrHoldNotActive
+		if errors.As(err, &errHoldNotActive) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errLoanLimitReached apperror.ErrLoanLimitReached
+		if errors.As(err, &errLoanLimitReached) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errHasOverdueLoans apperror.ErrHasOverdueLoans
+		if errors.As(err, &errHasOverdueLoans) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errDuplicateLoan apperror.ErrDuplicateLoan
+		if errors.As(err, &errDuplicateLoan) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errUnpaidFines apperror.ErrUnpaidFines
+		if errors.As(err, &errUnpaidFines) {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errAmountExceedsBalance apperror.ErrAmountExceedsBalance
+		if errors.As(err, &errAmountExceedsBalance) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "amount",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errUserNotFound apperror.ErrUserNotFound
+		if errors.As(err, &errUserNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errBookNotDeleted apperror.ErrBookNotDeleted
+		if errors.As(err, &errBookNotDeleted) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errInvalidId apperror.ErrInvalidId
+		if errors.As(err, &errInvalidId) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "id",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errAuthorHasBooks apperror.ErrAuthorHasBooks
+		if errors.As(err, &errAuthorHasBooks) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errInvalidSort apperror.ErrInvalidSort
+		if errors.As(err, &errInvalidSort) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "sort",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errInvalidCursor apperror.ErrInvalidCursor
+		if errors.As(err, &errInvalidCursor) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "cursor",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errMixedPagination apperror.ErrMixedPagination
+		if errors.As(err, &errMixedPagination) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "page",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errInvalidQuantityRange apperror.ErrInvalidQuantityRange
+		if errors.As(err, &errInvalidQuantityRange) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "quantity_max",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errInvalidDateRange apperror.ErrInvalidDateRange
+		if errors.As(err, &errInvalidDateRange) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "created_before",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errInvalidBorrowDateRange apperror.ErrInvalidBorrowDateRange
+		if errors.As(err, &errInvalidBorrowDateRange) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "borrowed_before",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errCopyNotFound apperror.ErrCopyNotFound
+		if errors.As(err, &errCopyNotFound) {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errCopyUnavailable apperror.ErrCopyUnavailable
+		if errors.As(err, &errCopyUnavailable) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errDuplicateBarcode apperror.ErrDuplicateBarcode
+		if errors.As(err, &errDuplicateBarcode) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "barcode",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errInvalidCopyTransition apperror.ErrInvalidCopyTransition
+		if errors.As(err, &errInvalidCopyTransition) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errCopyHasUnresolvedLoan apperror.ErrCopyHasUnresolvedLoan
+		if errors.As(err, &errCopyHasUnresolvedLoan) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errInvalidBorrowTransition apperror.ErrInvalidBorrowTransition
+		if errors.As(err, &errInvalidBorrowTransition) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errBorrowerRequired apperror.ErrBorrowerRequired
+		if errors.As(err, &errBorrowerRequired) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "user_id",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errBorrowerNotMember apperror.ErrBorrowerNotMember
+		if errors.As(err, &errBorrowerNotMember) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "user_id",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errReplacementChargeNotAllowed apperror.ErrReplacementChargeNotAllowed
+		if errors.As(err, &errReplacementChargeNotAllowed) {
+			fieldErrors = append(fieldErrors, util.FieldError{
+				Field:   "replacement_charge",
+				Message: err.Error(),
+			})
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": fieldErrors})
+			return
+		}
+
+		var errInvalidIdempotencyKey apperror.ErrInvalidIdempotencyKey
+		if errors.As(err, &errInvalidIdempotencyKey) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errIdempotencyKeyReused apperror.ErrIdempotencyKeyReused
+		if errors.As(err, &errIdempotencyKeyReused) {
+			ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errIdempotencyKeyInProgress apperror.ErrIdempotencyKeyInProgress
+		if errors.As(err, &errIdempotencyKeyInProgress) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errNotReady apperror.ErrNotReady
+		if errors.As(err, &errNotReady) {
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+			return
+		}
+
+		var errShuttingDown apperror.ErrShuttingDown
+		if errors.As(err, &errShuttingDown) {
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"message": err.Error()})
+			return
+		}
+
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "Server error"})
 		return
 	}
@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/entity"
+	"archive_lib/util/logger"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyMaxLength = 255
+
+	// reserveAttempts bounds the retries of a reservation racing with the release of the same key
+	reserveAttempts = 3
+)
+
+type IdempotencyStore interface {
+	ReserveKey(ctx context.Context, key *entity.IdempotencyKey, ttl time.Duration, lease time.Duration) (int64, error)
+	GetKey(ctx context.Context, userId int, key string) (*entity.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error
+	ReleaseKey(ctx context.Context, key *entity.IdempotencyKey) error
+}
+
+var (
+	idempotencyStore IdempotencyStore
+	idempotencyTTL   time.Duration
+	idempotencyLease time.Duration
+)
+
+// SetIdempotencyStore enables IdempotencyMiddleware, keeping the responses it stores for ttl.
+// A key whose request has not answered within lease can be taken over by a retry, so that
+// a request lost with its server does not block the key until it expires; a request that was
+// only slow then finds its key gone and stores nothing.
+func SetIdempotencyStore(store IdempotencyStore, ttl time.Duration, lease time.Duration) {
+	idempotencyStore = store
+	idempotencyTTL = ttl
+	idempotencyLease = lease
+}
+
+// responseRecorder keeps a copy of the response body written by the handler.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// IdempotencyMiddleware replays the stored response of a request retried with the same
+// Idempotency-Key header, and rejects the key when it comes with a different request.
+// Only successful responses are stored; a failed request releases its key so it can be retried.
+// It runs after AuthMiddleware, since keys are scoped to the subject of the token.
+func IdempotencyMiddleware(ctx *gin.Context) {
+	header := ctx.GetHeader("Idempotency-Key")
+	if header == "" || idempotencyStore == nil || ctx.IsAborted() || len(ctx.Errors) > 0 {
+		return
+	}
+	if len(header) > idempotencyKeyMaxLength {
+		ctx.Error(apperror.ErrInvalidIdempotencyKey{})
+		ctx.Abort()
+		return
+	}
+
+	userId, err := strconv.Atoi(ctx.GetString("subject"))
+	if err != nil {
+		ctx.Error(apperror.ErrRequestUnrecognized{})
+		ctx.Abort()
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	fingerprint := sha256.New()
+	fingerprint.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.Path + "\n"))
+	fingerprint.Write(body)
+
+	key := &entity.IdempotencyKey{
+		UserId:      userId,
+		Key:         header,
+		Fingerprint: hex.EncodeToString(fingerprint.Sum(nil)),
+	}
+
+	stored, err := reserveKey(ctx, key)
+	if err != nil {
+		ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if stored != nil {
+		switch {
+		case stored.Fingerprint != key.Fingerprint:
+			ctx.Error(apperror.ErrIdempotencyKeyReused{})
+			ctx.Abort()
+		case stored.StatusCode == 0:
+			ctx.Error(apperror.ErrIdempotencyKeyInProgress{})
+			ctx.Abort()
+		default:
+			ctx.Header("Idempotent-Replayed", "true")
+			ctx.Data(stored.StatusCode, gin.MIMEJSON+"; charset=utf-8", stored.ResponseBody)
+			ctx.Abort()
+		}
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+	ctx.Writer = recorder
+
+	ctx.Next()
+
+	// error responses are only written later by ErrorMiddleware, so they are never stored
+	if len(ctx.Errors) > 0 || recorder.Status() >= http.StatusInternalServerError {
+		err = idempotencyStore.ReleaseKey(ctx, key)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("releasing idempotency key: %v", err)
+		}
+		return
+	}
+
+	key.StatusCode = recorder.Status()
+	key.ResponseBody = recorder.body.Bytes()
+	err = idempotencyStore.SaveResponse(ctx, key)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("saving idempotent response: %v", err)
+	}
+}
+
+// reserveKey claims the key for the request, setting key.Reservation, or returns the earlier
+// request holding it. A key released between the two queries is claimed again.
+func reserveKey(ctx context.Context, key *entity.IdempotencyKey) (*entity.IdempotencyKey, error) {
+	for attempt := 1; ; attempt++ {
+		reservation, err := idempotencyStore.ReserveKey(ctx, key, idempotencyTTL, idempotencyLease)
+		if err != nil {
+			return nil, err
+		}
+		if reservation != 0 {
+			key.Reservation = reservation
+			return nil, nil
+		}
+
+		stored, err := idempotencyStore.GetKey(ctx, key.UserId, key.Key)
+		if errors.Is(err, sql.ErrNoRows) && attempt < reserveAttempts {
+			continue
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrIdempotencyKeyInProgress{}
+		}
+
+		return stored, err
+	}
+}
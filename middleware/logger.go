@@ -8,7 +8,7 @@ import (
 )
 
 func LoggerMiddleware(ctx *gin.Context) {
-	log := logger.Log
+	log := logger.FromContext(ctx)
 
 	startTime := time.Now()
 	ctx.Next()
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"archive_lib/util/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware counts requests and observes their latency by route template, e.g. /books/:id,
+// so that ids in the path do not create a series each. Requests matching no route share one label.
+func MetricsMiddleware(ctx *gin.Context) {
+	startTime := time.Now()
+	ctx.Next()
+
+	route := ctx.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	status := strconv.Itoa(ctx.Writer.Status())
+
+	metrics.HTTPRequests.WithLabelValues(ctx.Request.Method, route, status).Inc()
+	metrics.HTTPRequestDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(startTime).Seconds())
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"archive_lib/util/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/semconv/v1.17.0/httpconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts the server span of a request, continuing the trace named by its W3C
+// traceparent header if any. The span is carried by the request context, which the router falls
+// back to, so the spans started further down with the gin context become its children.
+func TracingMiddleware(ctx *gin.Context) {
+	route := ctx.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+
+	parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+	spanCtx, span := tracing.Start(parent, ctx.Request.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(httpconv.ServerRequest("", ctx.Request)...),
+		trace.WithAttributes(semconv.HTTPRoute(route)),
+	)
+	defer span.End()
+
+	ctx.Request = ctx.Request.WithContext(spanCtx)
+	ctx.Next()
+
+	status := ctx.Writer.Status()
+	span.SetAttributes(semconv.HTTPStatusCode(status))
+	if err := ctx.Errors.Last(); err != nil {
+		span.RecordError(err.Err)
+	}
+	code, description := httpconv.ServerStatus(status)
+	span.SetStatus(code, description)
+}
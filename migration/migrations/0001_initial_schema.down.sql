@@ -0,0 +1,4 @@
+DROP TABLE borrowing_records;
+DROP TABLE users;
+DROP TABLE books;
+DROP TABLE authors;
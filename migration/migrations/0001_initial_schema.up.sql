@@ -1,4 +1,6 @@
-CREATE TABLE authors (
+-- the schema.sql the project started from; IF NOT EXISTS lets a database created from it be adopted as is
+
+CREATE TABLE IF NOT EXISTS authors (
 	id BIGSERIAL PRIMARY KEY,
 	name VARCHAR NOT NULL,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -6,7 +8,7 @@ CREATE TABLE authors (
 	deleted_at TIMESTAMP NULL
 );
 
-CREATE TABLE books (
+CREATE TABLE IF NOT EXISTS books (
 	id BIGSERIAL PRIMARY KEY,
 	title VARCHAR NOT NULL UNIQUE,
 	author_id BIGINT NOT NULL,
@@ -19,7 +21,7 @@ CREATE TABLE books (
 	deleted_at TIMESTAMP NULL
 );
 
-CREATE TABLE users (
+CREATE TABLE IF NOT EXISTS users (
 	id BIGSERIAL PRIMARY KEY,
 	username VARCHAR NOT NULL,
 	email VARCHAR NOT NULL,
@@ -29,7 +31,7 @@ CREATE TABLE users (
 	deleted_at TIMESTAMP NULL
 );
 
-CREATE TABLE borrowing_records (
+CREATE TABLE IF NOT EXISTS borrowing_records (
 	id BIGSERIAL PRIMARY KEY,
 	user_id BIGINT NOT NULL,
 	FOREIGN KEY(user_id) REFERENCES users(id),
@@ -0,0 +1,6 @@
+DROP TRIGGER authors_search_vector_trigger ON authors;
+DROP FUNCTION authors_search_vector_update();
+DROP TRIGGER books_search_vector_trigger ON books;
+DROP FUNCTION books_search_vector_update();
+DROP INDEX books_search_vector_idx;
+ALTER TABLE books DROP COLUMN search_vector;
@@ -0,0 +1,34 @@
+ALTER TABLE books ADD COLUMN search_vector TSVECTOR;
+
+CREATE INDEX books_search_vector_idx ON books USING GIN (search_vector);
+
+-- keeps books.search_vector in sync with the title, author name, and description
+CREATE FUNCTION books_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+	NEW.search_vector :=
+		setweight(to_tsvector('english', coalesce(NEW.title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce((SELECT name FROM authors WHERE id = NEW.author_id), '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(NEW.description, '')), 'C');
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER books_search_vector_trigger
+	BEFORE INSERT OR UPDATE OF title, description, author_id ON books
+	FOR EACH ROW EXECUTE FUNCTION books_search_vector_update();
+
+-- re-indexes the books of an author whose name changed
+CREATE FUNCTION authors_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+	UPDATE books SET title = title WHERE author_id = NEW.id;
+	RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER authors_search_vector_trigger
+	AFTER UPDATE OF name ON authors
+	FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name)
+	EXECUTE FUNCTION authors_search_vector_update();
+
+-- indexes the books that already exist
+UPDATE books SET title = title;
@@ -0,0 +1,2 @@
+-- fails if two accounts already share an email; merge them before migrating
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
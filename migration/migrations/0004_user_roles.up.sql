@@ -0,0 +1,2 @@
+-- existing accounts become members; promote librarians by hand
+ALTER TABLE users ADD COLUMN role VARCHAR NOT NULL DEFAULT 'member' CHECK (role IN ('librarian', 'member'));
@@ -0,0 +1,23 @@
+-- refresh tokens are stored hashed; tokens rotated from the same login share a family_id
+CREATE TABLE refresh_tokens (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL,
+	FOREIGN KEY(user_id) REFERENCES users(id),
+	family_id VARCHAR NOT NULL,
+	token_hash VARCHAR NOT NULL UNIQUE,
+	expires_at TIMESTAMPTZ NOT NULL,
+	rotated_at TIMESTAMPTZ NULL,
+	revoked_at TIMESTAMPTZ NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+CREATE INDEX refresh_tokens_user_id_idx ON refresh_tokens (user_id);
+
+-- access tokens revoked before expiry, keyed by their jti claim
+CREATE TABLE revoked_tokens (
+	jti VARCHAR PRIMARY KEY,
+	expires_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,4 @@
+DROP TABLE loan_renewals;
+ALTER TABLE borrowing_records DROP COLUMN renewal_count;
+DROP INDEX borrowing_records_due_date_idx;
+ALTER TABLE borrowing_records DROP COLUMN due_date;
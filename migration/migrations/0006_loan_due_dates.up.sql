@@ -0,0 +1,19 @@
+-- loans made before due dates existed get the default loan period of 14 days
+ALTER TABLE borrowing_records ADD COLUMN due_date TIMESTAMP NULL;
+UPDATE borrowing_records SET due_date = borrowing_date + INTERVAL '14 days';
+ALTER TABLE borrowing_records ALTER COLUMN due_date SET NOT NULL;
+
+-- speeds up the overdue lookup of loans still out
+CREATE INDEX borrowing_records_due_date_idx ON borrowing_records (due_date) WHERE returning_date IS NULL;
+
+ALTER TABLE borrowing_records ADD COLUMN renewal_count INT NOT NULL DEFAULT 0;
+
+-- audit trail of loan renewals
+CREATE TABLE loan_renewals (
+	id BIGSERIAL PRIMARY KEY,
+	borrowing_record_id BIGINT NOT NULL,
+	FOREIGN KEY(borrowing_record_id) REFERENCES borrowing_records(id),
+	previous_due_date TIMESTAMP NOT NULL,
+	new_due_date TIMESTAMP NOT NULL,
+	renewed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
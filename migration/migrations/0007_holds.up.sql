@@ -0,0 +1,15 @@
+-- FIFO waitlist of members for out of stock books; a ready hold has a copy set aside until expires_at
+CREATE TABLE holds (
+	id BIGSERIAL PRIMARY KEY,
+	book_id BIGINT NOT NULL,
+	FOREIGN KEY(book_id) REFERENCES books(id),
+	user_id BIGINT NOT NULL,
+	FOREIGN KEY(user_id) REFERENCES users(id),
+	status VARCHAR NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired')),
+	expires_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX holds_book_id_status_idx ON holds (book_id, status, created_at);
+CREATE UNIQUE INDEX holds_active_user_book_idx ON holds (book_id, user_id) WHERE status IN ('waiting', 'ready');
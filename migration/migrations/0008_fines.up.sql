@@ -0,0 +1,16 @@
+-- fines ledger; amounts are in minor currency units, the balance is charges minus payments and waivers
+CREATE TABLE fines (
+	id BIGSERIAL PRIMARY KEY,
+	user_id BIGINT NOT NULL,
+	FOREIGN KEY(user_id) REFERENCES users(id),
+	borrowing_record_id BIGINT NULL,
+	FOREIGN KEY(borrowing_record_id) REFERENCES borrowing_records(id),
+	kind VARCHAR NOT NULL CHECK (kind IN ('charge', 'payment', 'waiver')),
+	amount BIGINT NOT NULL CHECK (amount > 0),
+	note VARCHAR NOT NULL DEFAULT '',
+	created_by BIGINT NULL,
+	FOREIGN KEY(created_by) REFERENCES users(id),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX fines_user_id_idx ON fines (user_id);
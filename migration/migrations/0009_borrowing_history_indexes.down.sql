@@ -0,0 +1,2 @@
+DROP INDEX borrowing_records_book_id_idx;
+DROP INDEX borrowing_records_user_id_idx;
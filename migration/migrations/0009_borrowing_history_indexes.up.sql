@@ -0,0 +1,2 @@
+CREATE INDEX borrowing_records_user_id_idx ON borrowing_records (user_id, borrowing_date);
+CREATE INDEX borrowing_records_book_id_idx ON borrowing_records (book_id, borrowing_date);
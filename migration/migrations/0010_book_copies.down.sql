@@ -0,0 +1,7 @@
+ALTER TABLE books ADD COLUMN quantity INTEGER NOT NULL DEFAULT 0;
+UPDATE books b SET quantity = (SELECT COUNT(*) FROM book_copies c WHERE c.book_id = b.id AND c.status = 'available');
+ALTER TABLE books ALTER COLUMN quantity DROP DEFAULT;
+
+ALTER TABLE holds DROP COLUMN copy_id;
+ALTER TABLE borrowing_records DROP COLUMN copy_id;
+DROP TABLE book_copies;
@@ -0,0 +1,76 @@
+-- physical copies of a book; the quantity of a book is the number of its available copies
+CREATE TABLE book_copies (
+	id BIGSERIAL PRIMARY KEY,
+	book_id BIGINT NOT NULL,
+	FOREIGN KEY(book_id) REFERENCES books(id),
+	barcode VARCHAR NOT NULL UNIQUE,
+	condition VARCHAR NOT NULL DEFAULT 'good' CHECK (condition IN ('new', 'good', 'fair', 'poor')),
+	location VARCHAR NOT NULL DEFAULT '',
+	status VARCHAR NOT NULL DEFAULT 'available' CHECK (status IN ('available', 'borrowed', 'reserved', 'withdrawn')),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX book_copies_book_id_status_idx ON book_copies (book_id, status);
+
+ALTER TABLE borrowing_records ADD COLUMN copy_id BIGINT NULL REFERENCES book_copies(id);
+
+ALTER TABLE holds ADD COLUMN copy_id BIGINT NULL REFERENCES book_copies(id); -- the copy set aside for a ready hold
+
+-- the stock of every book becomes available copies, barcoded BK<book id>-<copy number> like new books
+INSERT INTO book_copies (book_id, barcode)
+SELECT b.id, 'BK' || LPAD(b.id::TEXT, 6, '0') || '-' || LPAD(n::TEXT, 3, '0')
+FROM books b, generate_series(1, b.quantity) n;
+
+-- the stock did not count the books out on loan, so each open loan gets a borrowed copy numbered after it
+WITH open_loans AS (
+	SELECT
+		r.id,
+		r.book_id,
+		'BK' || LPAD(r.book_id::TEXT, 6, '0') || '-' || LPAD((b.quantity + ROW_NUMBER() OVER (PARTITION BY r.book_id ORDER BY r.id))::TEXT, 3, '0') AS barcode
+	FROM borrowing_records r
+	JOIN books b ON b.id = r.book_id
+	WHERE r.returning_date IS NULL
+), copies AS (
+	INSERT INTO book_copies (book_id, barcode, status)
+	SELECT book_id, barcode, 'borrowed' FROM open_loans
+	RETURNING id, barcode
+)
+UPDATE borrowing_records r SET copy_id = c.id
+FROM open_loans l
+JOIN copies c ON c.barcode = l.barcode
+WHERE r.id = l.id;
+
+-- nor the books set aside for ready holds, which get a reserved copy each
+WITH ready_holds AS (
+	SELECT
+		h.id,
+		h.book_id,
+		'BK' || LPAD(h.book_id::TEXT, 6, '0') || '-' || LPAD((
+			(SELECT COUNT(*) FROM book_copies c WHERE c.book_id = h.book_id) + ROW_NUMBER() OVER (PARTITION BY h.book_id ORDER BY h.id)
+		)::TEXT, 3, '0') AS barcode
+	FROM holds h
+	WHERE h.status = 'ready'
+), copies AS (
+	INSERT INTO book_copies (book_id, barcode, status)
+	SELECT book_id, barcode, 'reserved' FROM ready_holds
+	RETURNING id, barcode
+)
+UPDATE holds h SET copy_id = c.id
+FROM ready_holds rh
+JOIN copies c ON c.barcode = rh.barcode
+WHERE h.id = rh.id;
+
+-- returned loans point at a copy of their book; a book left without any copy gets a withdrawn one for its history
+INSERT INTO book_copies (book_id, barcode, status)
+SELECT DISTINCT r.book_id, 'BK' || LPAD(r.book_id::TEXT, 6, '0') || '-001', 'withdrawn'
+FROM borrowing_records r
+WHERE r.copy_id IS NULL
+	AND NOT EXISTS (SELECT 1 FROM book_copies c WHERE c.book_id = r.book_id);
+
+UPDATE borrowing_records r SET copy_id = (SELECT MIN(c.id) FROM book_copies c WHERE c.book_id = r.book_id)
+WHERE r.copy_id IS NULL;
+
+ALTER TABLE borrowing_records ALTER COLUMN copy_id SET NOT NULL;
+
+ALTER TABLE books DROP COLUMN quantity;
@@ -0,0 +1,6 @@
+ALTER TABLE borrowing_records DROP CONSTRAINT borrowing_records_status_check;
+
+UPDATE book_copies SET status = 'withdrawn' WHERE status IN ('lost', 'damaged');
+ALTER TABLE book_copies DROP CONSTRAINT book_copies_status_check;
+ALTER TABLE book_copies ADD CONSTRAINT book_copies_status_check
+	CHECK (status IN ('available', 'borrowed', 'reserved', 'withdrawn'));
@@ -0,0 +1,7 @@
+ALTER TABLE book_copies DROP CONSTRAINT book_copies_status_check;
+ALTER TABLE book_copies ADD CONSTRAINT book_copies_status_check
+	CHECK (status IN ('available', 'borrowed', 'reserved', 'withdrawn', 'lost', 'damaged'));
+
+-- returning_date closes a loan: it is set once the record is returned, damaged on return, or lost
+ALTER TABLE borrowing_records ADD CONSTRAINT borrowing_records_status_check
+	CHECK (status IN ('borrowed', 'returned', 'lost', 'damaged_on_return', 'claimed_returned'));
@@ -0,0 +1 @@
+ALTER TABLE borrowing_records DROP COLUMN checked_out_by;
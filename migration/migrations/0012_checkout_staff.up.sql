@@ -0,0 +1 @@
+ALTER TABLE borrowing_records ADD COLUMN checked_out_by BIGINT NULL REFERENCES users(id); -- the librarian who lent the book at the desk
@@ -0,0 +1,13 @@
+-- responses to write requests sent with an Idempotency-Key header, replayed on retries until expires_at;
+-- status_code stays NULL while the first request is in flight
+CREATE TABLE idempotency_keys (
+	user_id BIGINT NOT NULL,
+	FOREIGN KEY(user_id) REFERENCES users(id),
+	idempotency_key VARCHAR NOT NULL,
+	fingerprint VARCHAR NOT NULL, -- sha256 of the method, path, and body of the request
+	status_code INT NULL,
+	response_body BYTEA NULL,
+	expires_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (user_id, idempotency_key)
+);
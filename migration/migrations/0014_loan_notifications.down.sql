@@ -0,0 +1 @@
+DROP TABLE loan_notifications;
@@ -0,0 +1,12 @@
+-- reminders sent to borrowers, one per loan, kind, and due date so a renewed loan is reminded again
+CREATE TABLE loan_notifications (
+	id BIGSERIAL PRIMARY KEY,
+	borrowing_record_id BIGINT NOT NULL,
+	FOREIGN KEY(borrowing_record_id) REFERENCES borrowing_records(id),
+	user_id BIGINT NOT NULL,
+	FOREIGN KEY(user_id) REFERENCES users(id),
+	kind VARCHAR NOT NULL CHECK (kind IN ('due_soon', 'overdue')),
+	due_date TIMESTAMP NOT NULL,
+	sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (borrowing_record_id, kind, due_date)
+);
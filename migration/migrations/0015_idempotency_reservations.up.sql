@@ -0,0 +1,7 @@
+-- every reservation of a key gets a new number, so that a request whose key was taken over
+-- after its lease cannot store or release the key of the request that took it over
+CREATE SEQUENCE idempotency_key_reservations;
+
+ALTER TABLE idempotency_keys ADD COLUMN reservation BIGINT NOT NULL DEFAULT nextval('idempotency_key_reservations');
+
+ALTER SEQUENCE idempotency_key_reservations OWNED BY idempotency_keys.reservation;
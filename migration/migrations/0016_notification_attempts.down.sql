@@ -0,0 +1,10 @@
+DELETE FROM loan_notifications WHERE status <> 'sent';
+
+ALTER TABLE loan_notifications 
+	DROP COLUMN status, 
+	DROP COLUMN attempts, 
+	DROP COLUMN attempted_at, 
+	DROP COLUMN next_attempt_at, 
+	DROP COLUMN last_error, 
+	ALTER COLUMN sent_at SET DEFAULT CURRENT_TIMESTAMP, 
+	ALTER COLUMN sent_at SET NOT NULL;
@@ -0,0 +1,11 @@
+-- a reminder is claimed and committed before it is delivered, then marked sent or failed, so that a
+-- delivery never runs inside a transaction; a failed one is retried after next_attempt_at until it has
+-- used up its attempts, and one left sending by a crash is not sent again
+ALTER TABLE loan_notifications 
+	ADD COLUMN status VARCHAR NOT NULL DEFAULT 'sent' CHECK (status IN ('sending', 'sent', 'failed')), 
+	ADD COLUMN attempts INT NOT NULL DEFAULT 1, 
+	ADD COLUMN attempted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, 
+	ADD COLUMN next_attempt_at TIMESTAMP NULL, 
+	ADD COLUMN last_error VARCHAR NULL, 
+	ALTER COLUMN sent_at DROP NOT NULL, 
+	ALTER COLUMN sent_at DROP DEFAULT;
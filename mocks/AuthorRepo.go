@@ -0,0 +1,187 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "archive_lib/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthorRepo is an autogenerated mock type for the AuthorRepo type
+type AuthorRepo struct {
+	mock.Mock
+}
+
+// AddAuthor provides a mock function with given fields: ctx, author
+func (_m *AuthorRepo) AddAuthor(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	ret := _m.Called(ctx, author)
+
+	var r0 *entity.Author
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Author) *entity.Author); ok {
+		r0 = rf(ctx, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Author) error); ok {
+		r1 = rf(ctx, author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAuthor provides a mock function with given fields: ctx, id
+func (_m *AuthorRepo) DeleteAuthor(ctx context.Context, id int) (*entity.Author, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Author
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Author); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthorById provides a mock function with given fields: ctx, id
+func (_m *AuthorRepo) GetAuthorById(ctx context.Context, id int) (*entity.Author, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Author
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Author); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasActiveBooks provides a mock function with given fields: ctx, id
+func (_m *AuthorRepo) HasActiveBooks(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsAuthorExisted provides a mock function with given fields: ctx, id
+func (_m *AuthorRepo) IsAuthorExisted(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuthors provides a mock function with given fields: ctx
+func (_m *AuthorRepo) ListAuthors(ctx context.Context) ([]entity.Author, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.Author
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.Author); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAuthor provides a mock function with given fields: ctx, author
+func (_m *AuthorRepo) UpdateAuthor(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	ret := _m.Called(ctx, author)
+
+	var r0 *entity.Author
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Author) *entity.Author); ok {
+		r0 = rf(ctx, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Author)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Author) error); ok {
+		r1 = rf(ctx, author)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAuthorRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuthorRepo creates a new instance of AuthorRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuthorRepo(t mockConstructorTestingTNewAuthorRepo) *AuthorRepo {
+	mock := &AuthorRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,168 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "archive_lib/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthorUsecase is an autogenerated mock type for the AuthorUsecase type
+type AuthorUsecase struct {
+	mock.Mock
+}
+
+// AddAuthor provides a mock function with given fields: ctx, authorRequest
+func (_m *AuthorUsecase) AddAuthor(ctx context.Context, authorRequest *dto.AuthorRequest) (*dto.AuthorResponse, error) {
+	ret := _m.Called(ctx, authorRequest)
+
+	var r0 *dto.AuthorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuthorRequest) *dto.AuthorResponse); ok {
+		r0 = rf(ctx, authorRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AuthorResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuthorRequest) error); ok {
+		r1 = rf(ctx, authorRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAuthor provides a mock function with given fields: ctx, id
+func (_m *AuthorUsecase) DeleteAuthor(ctx context.Context, id int) (*dto.AuthorResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dto.AuthorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *dto.AuthorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AuthorResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthor provides a mock function with given fields: ctx, id
+func (_m *AuthorUsecase) GetAuthor(ctx context.Context, id int) (*dto.AuthorResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dto.AuthorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *dto.AuthorResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AuthorResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAuthorBooks provides a mock function with given fields: ctx, id
+func (_m *AuthorUsecase) GetAuthorBooks(ctx context.Context, id int) (*dto.AuthorBooksResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dto.AuthorBooksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *dto.AuthorBooksResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AuthorBooksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAuthors provides a mock function with given fields: ctx
+func (_m *AuthorUsecase) ListAuthors(ctx context.Context) ([]*dto.AuthorResponse, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*dto.AuthorResponse
+	if rf, ok := ret.Get(0).(func(context.Context) []*dto.AuthorResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dto.AuthorResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAuthor provides a mock function with given fields: ctx, id, authorRequest
+func (_m *AuthorUsecase) UpdateAuthor(ctx context.Context, id int, authorRequest *dto.AuthorRequest) (*dto.AuthorResponse, error) {
+	ret := _m.Called(ctx, id, authorRequest)
+
+	var r0 *dto.AuthorResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, *dto.AuthorRequest) *dto.AuthorResponse); ok {
+		r0 = rf(ctx, id, authorRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AuthorResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *dto.AuthorRequest) error); ok {
+		r1 = rf(ctx, id, authorRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAuthorUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAuthorUsecase creates a new instance of AuthorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAuthorUsecase(t mockConstructorTestingTNewAuthorUsecase) *AuthorUsecase {
+	mock := &AuthorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
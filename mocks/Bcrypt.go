@@ -23,6 +23,29 @@ func (_m *Bcrypt) CompareHashAndPassword(hashedPassword []byte, password []byte)
 	return r0
 }
 
+// GenerateFromPassword provides a mock function with given fields: password
+func (_m *Bcrypt) GenerateFromPassword(password []byte) ([]byte, error) {
+	ret := _m.Called(password)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte) []byte); ok {
+		r0 = rf(password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewBcrypt interface {
 	mock.TestingT
 	Cleanup(func())
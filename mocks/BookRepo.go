@@ -37,18 +37,115 @@ func (_m *BookRepo) AddBook(ctx context.Context, bookPost *entity.BookPost) (*en
 	return r0, r1
 }
 
-// DecrementStock provides a mock function with given fields: ctx, id
-func (_m *BookRepo) DecrementStock(ctx context.Context, id int) error {
+// CountBooks provides a mock function with given fields: ctx, query
+func (_m *BookRepo) CountBooks(ctx context.Context, query *entity.BookQuery) (int, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookQuery) int); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSearchBooks provides a mock function with given fields: ctx, search
+func (_m *BookRepo) CountSearchBooks(ctx context.Context, search *entity.BookSearch) (int, error) {
+	ret := _m.Called(ctx, search)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookSearch) int); ok {
+		r0 = rf(ctx, search)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookSearch) error); ok {
+		r1 = rf(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteBook provides a mock function with given fields: ctx, id
+func (_m *BookRepo) DeleteBook(ctx context.Context, id int) (*entity.Book, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+	var r0 *entity.Book
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Book); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
+}
+
+// GetBookById provides a mock function with given fields: ctx, id
+func (_m *BookRepo) GetBookById(ctx context.Context, id int) (*entity.Book, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Book
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Book); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBooksByAuthorId provides a mock function with given fields: ctx, authorId
+func (_m *BookRepo) GetBooksByAuthorId(ctx context.Context, authorId int) ([]entity.Book, error) {
+	ret := _m.Called(ctx, authorId)
+
+	var r0 []entity.Book
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.Book); ok {
+		r0 = rf(ctx, authorId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, authorId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetBooksByTitle provides a mock function with given fields: ctx, title
@@ -74,22 +171,29 @@ func (_m *BookRepo) GetBooksByTitle(ctx context.Context, title string) ([]entity
 	return r0, r1
 }
 
-// IncrementStock provides a mock function with given fields: ctx, id
-func (_m *BookRepo) IncrementStock(ctx context.Context, id int) error {
+// IsAuthorExisted provides a mock function with given fields: ctx, id
+func (_m *BookRepo) IsAuthorExisted(ctx context.Context, id int) (bool, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// IsAuthorExisted provides a mock function with given fields: ctx, id
-func (_m *BookRepo) IsAuthorExisted(ctx context.Context, id int) (bool, error) {
+// IsBookExisted provides a mock function with given fields: ctx, id
+func (_m *BookRepo) IsBookExisted(ctx context.Context, id int) (bool, error) {
 	ret := _m.Called(ctx, id)
 
 	var r0 bool
@@ -109,8 +213,8 @@ func (_m *BookRepo) IsAuthorExisted(ctx context.Context, id int) (bool, error) {
 	return r0, r1
 }
 
-// IsBookExisted provides a mock function with given fields: ctx, id
-func (_m *BookRepo) IsBookExisted(ctx context.Context, id int) (bool, error) {
+// IsDeletedBookExisted provides a mock function with given fields: ctx, id
+func (_m *BookRepo) IsDeletedBookExisted(ctx context.Context, id int) (bool, error) {
 	ret := _m.Called(ctx, id)
 
 	var r0 bool
@@ -172,6 +276,27 @@ func (_m *BookRepo) IsTitleExisted(ctx context.Context, title string) (bool, err
 	return r0, r1
 }
 
+// IsTitleExistedForOther provides a mock function with given fields: ctx, title, id
+func (_m *BookRepo) IsTitleExistedForOther(ctx context.Context, title string, id int) (bool, error) {
+	ret := _m.Called(ctx, title, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) bool); ok {
+		r0 = rf(ctx, title, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, title, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListBooks provides a mock function with given fields: ctx
 func (_m *BookRepo) ListBooks(ctx context.Context) ([]entity.Book, error) {
 	ret := _m.Called(ctx)
@@ -195,6 +320,98 @@ func (_m *BookRepo) ListBooks(ctx context.Context) ([]entity.Book, error) {
 	return r0, r1
 }
 
+// ListBooksPage provides a mock function with given fields: ctx, query
+func (_m *BookRepo) ListBooksPage(ctx context.Context, query *entity.BookQuery) ([]entity.Book, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []entity.Book
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookQuery) []entity.Book); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBook provides a mock function with given fields: ctx, id
+func (_m *BookRepo) RestoreBook(ctx context.Context, id int) (*entity.Book, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Book
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Book); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchBooks provides a mock function with given fields: ctx, search
+func (_m *BookRepo) SearchBooks(ctx context.Context, search *entity.BookSearch) ([]entity.BookSearchResult, error) {
+	ret := _m.Called(ctx, search)
+
+	var r0 []entity.BookSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookSearch) []entity.BookSearchResult); ok {
+		r0 = rf(ctx, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.BookSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookSearch) error); ok {
+		r1 = rf(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBook provides a mock function with given fields: ctx, bookPost
+func (_m *BookRepo) UpdateBook(ctx context.Context, bookPost *entity.BookPost) (*entity.Book, error) {
+	ret := _m.Called(ctx, bookPost)
+
+	var r0 *entity.Book
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookPost) *entity.Book); ok {
+		r0 = rf(ctx, bookPost)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Book)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookPost) error); ok {
+		r1 = rf(ctx, bookPost)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewBookRepo interface {
 	mock.TestingT
 	Cleanup(func())
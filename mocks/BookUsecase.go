@@ -37,6 +37,29 @@ func (_m *BookUsecase) AddBook(ctx context.Context, bookRequest *dto.BookRequest
 	return r0, r1
 }
 
+// DeleteBook provides a mock function with given fields: ctx, id
+func (_m *BookUsecase) DeleteBook(ctx context.Context, id int) (*dto.BookResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dto.BookResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *dto.BookResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BookResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBooksByTitle provides a mock function with given fields: ctx, title
 func (_m *BookUsecase) GetBooksByTitle(ctx context.Context, title string) ([]*dto.BookResponse, error) {
 	ret := _m.Called(ctx, title)
@@ -83,6 +106,121 @@ func (_m *BookUsecase) ListBooks(ctx context.Context) ([]*dto.BookResponse, erro
 	return r0, r1
 }
 
+// ListBooksPage provides a mock function with given fields: ctx, bookListQuery
+func (_m *BookUsecase) ListBooksPage(ctx context.Context, bookListQuery *dto.BookListQuery) (*dto.BookPageResponse, error) {
+	ret := _m.Called(ctx, bookListQuery)
+
+	var r0 *dto.BookPageResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.BookListQuery) *dto.BookPageResponse); ok {
+		r0 = rf(ctx, bookListQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BookPageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.BookListQuery) error); ok {
+		r1 = rf(ctx, bookListQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchBook provides a mock function with given fields: ctx, id, bookPatchRequest
+func (_m *BookUsecase) PatchBook(ctx context.Context, id int, bookPatchRequest *dto.BookPatchRequest) (*dto.BookResponse, error) {
+	ret := _m.Called(ctx, id, bookPatchRequest)
+
+	var r0 *dto.BookResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, *dto.BookPatchRequest) *dto.BookResponse); ok {
+		r0 = rf(ctx, id, bookPatchRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BookResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *dto.BookPatchRequest) error); ok {
+		r1 = rf(ctx, id, bookPatchRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBook provides a mock function with given fields: ctx, id
+func (_m *BookUsecase) RestoreBook(ctx context.Context, id int) (*dto.BookResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *dto.BookResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *dto.BookResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BookResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchBooks provides a mock function with given fields: ctx, bookSearchQuery
+func (_m *BookUsecase) SearchBooks(ctx context.Context, bookSearchQuery *dto.BookSearchQuery) (*dto.BookPageResponse, error) {
+	ret := _m.Called(ctx, bookSearchQuery)
+
+	var r0 *dto.BookPageResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.BookSearchQuery) *dto.BookPageResponse); ok {
+		r0 = rf(ctx, bookSearchQuery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BookPageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.BookSearchQuery) error); ok {
+		r1 = rf(ctx, bookSearchQuery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateBook provides a mock function with given fields: ctx, id, bookUpdateRequest
+func (_m *BookUsecase) UpdateBook(ctx context.Context, id int, bookUpdateRequest *dto.BookUpdateRequest) (*dto.BookResponse, error) {
+	ret := _m.Called(ctx, id, bookUpdateRequest)
+
+	var r0 *dto.BookResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, *dto.BookUpdateRequest) *dto.BookResponse); ok {
+		r0 = rf(ctx, id, bookUpdateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BookResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *dto.BookUpdateRequest) error); ok {
+		r1 = rf(ctx, id, bookUpdateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewBookUsecase interface {
 	mock.TestingT
 	Cleanup(func())
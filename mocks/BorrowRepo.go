@@ -14,6 +14,83 @@ type BorrowRepo struct {
 	mock.Mock
 }
 
+// AddRenewal provides a mock function with given fields: ctx, renewal
+func (_m *BorrowRepo) AddRenewal(ctx context.Context, renewal *entity.Renewal) error {
+	ret := _m.Called(ctx, renewal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Renewal) error); ok {
+		r0 = rf(ctx, renewal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountActiveBorrows provides a mock function with given fields: ctx, userId
+func (_m *BorrowRepo) CountActiveBorrows(ctx context.Context, userId int) (int, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountBorrows provides a mock function with given fields: ctx, query
+func (_m *BorrowRepo) CountBorrows(ctx context.Context, query *entity.BorrowQuery) (int, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BorrowQuery) int); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BorrowQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetActiveBorrowIdByCopy provides a mock function with given fields: ctx, copyId
+func (_m *BorrowRepo) GetActiveBorrowIdByCopy(ctx context.Context, copyId int) (int, error) {
+	ret := _m.Called(ctx, copyId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, copyId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, copyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBookByBorrowId provides a mock function with given fields: ctx, id
 func (_m *BorrowRepo) GetBookByBorrowId(ctx context.Context, id int) (int, error) {
 	ret := _m.Called(ctx, id)
@@ -35,6 +112,92 @@ func (_m *BorrowRepo) GetBookByBorrowId(ctx context.Context, id int) (int, error
 	return r0, r1
 }
 
+// GetBorrowById provides a mock function with given fields: ctx, id
+func (_m *BorrowRepo) GetBorrowById(ctx context.Context, id int) (*entity.Borrow, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Borrow
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Borrow); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasActiveBorrow provides a mock function with given fields: ctx, userId, bookId
+func (_m *BorrowRepo) HasActiveBorrow(ctx context.Context, userId int, bookId int) (bool, error) {
+	ret := _m.Called(ctx, userId, bookId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, userId, bookId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userId, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasOverdueBorrows provides a mock function with given fields: ctx, userId
+func (_m *BorrowRepo) HasOverdueBorrows(ctx context.Context, userId int) (bool, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasUnresolvedBorrowByCopy provides a mock function with given fields: ctx, copyId
+func (_m *BorrowRepo) HasUnresolvedBorrowByCopy(ctx context.Context, copyId int) (bool, error) {
+	ret := _m.Called(ctx, copyId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, copyId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, copyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsBorrowExisted provides a mock function with given fields: ctx, id
 func (_m *BorrowRepo) IsBorrowExisted(ctx context.Context, id int) (bool, error) {
 	ret := _m.Called(ctx, id)
@@ -98,8 +261,77 @@ func (_m *BorrowRepo) IsUserAuthorized(ctx context.Context, record_id int, user_
 	return r0, r1
 }
 
-// Record provides a mock function with given fields: ctx, borrow
-func (_m *BorrowRepo) Record(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error) {
+// ListBorrows provides a mock function with given fields: ctx, query
+func (_m *BorrowRepo) ListBorrows(ctx context.Context, query *entity.BorrowQuery) ([]entity.Borrow, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []entity.Borrow
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BorrowQuery) []entity.Borrow); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BorrowQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Record provides a mock function with given fields: ctx, borrow, loanDays
+func (_m *BorrowRepo) Record(ctx context.Context, borrow *entity.Borrow, loanDays int) (*entity.Borrow, error) {
+	ret := _m.Called(ctx, borrow, loanDays)
+
+	var r0 *entity.Borrow
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Borrow, int) *entity.Borrow); ok {
+		r0 = rf(ctx, borrow, loanDays)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Borrow, int) error); ok {
+		r1 = rf(ctx, borrow, loanDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Renew provides a mock function with given fields: ctx, borrow, loanDays
+func (_m *BorrowRepo) Renew(ctx context.Context, borrow *entity.Borrow, loanDays int) (*entity.Borrow, error) {
+	ret := _m.Called(ctx, borrow, loanDays)
+
+	var r0 *entity.Borrow
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Borrow, int) *entity.Borrow); ok {
+		r0 = rf(ctx, borrow, loanDays)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Borrow)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Borrow, int) error); ok {
+		r1 = rf(ctx, borrow, loanDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Return provides a mock function with given fields: ctx, borrow
+func (_m *BorrowRepo) Return(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error) {
 	ret := _m.Called(ctx, borrow)
 
 	var r0 *entity.Borrow
@@ -121,8 +353,8 @@ func (_m *BorrowRepo) Record(ctx context.Context, borrow *entity.Borrow) (*entit
 	return r0, r1
 }
 
-// Return provides a mock function with given fields: ctx, borrow
-func (_m *BorrowRepo) Return(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error) {
+// UpdateStatus provides a mock function with given fields: ctx, borrow
+func (_m *BorrowRepo) UpdateStatus(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error) {
 	ret := _m.Called(ctx, borrow)
 
 	var r0 *entity.Borrow
@@ -14,6 +14,144 @@ type BorrowUsecase struct {
 	mock.Mock
 }
 
+// BatchRecord provides a mock function with given fields: ctx, batchRequest
+func (_m *BorrowUsecase) BatchRecord(ctx context.Context, batchRequest *dto.BatchBorrowRequest) (*dto.BatchResponse, error) {
+	ret := _m.Called(ctx, batchRequest)
+
+	var r0 *dto.BatchResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.BatchBorrowRequest) *dto.BatchResponse); ok {
+		r0 = rf(ctx, batchRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BatchResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.BatchBorrowRequest) error); ok {
+		r1 = rf(ctx, batchRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BatchReturn provides a mock function with given fields: ctx, batchRequest
+func (_m *BorrowUsecase) BatchReturn(ctx context.Context, batchRequest *dto.BatchReturnRequest) (*dto.BatchResponse, error) {
+	ret := _m.Called(ctx, batchRequest)
+
+	var r0 *dto.BatchResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.BatchReturnRequest) *dto.BatchResponse); ok {
+		r0 = rf(ctx, batchRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BatchResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.BatchReturnRequest) error); ok {
+		r1 = rf(ctx, batchRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Checkout provides a mock function with given fields: ctx, borrowRequest, staffId
+func (_m *BorrowUsecase) Checkout(ctx context.Context, borrowRequest *dto.BorrowRequest, staffId int) (*dto.BorrowResponse, error) {
+	ret := _m.Called(ctx, borrowRequest, staffId)
+
+	var r0 *dto.BorrowResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.BorrowRequest, int) *dto.BorrowResponse); ok {
+		r0 = rf(ctx, borrowRequest, staffId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BorrowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.BorrowRequest, int) error); ok {
+		r1 = rf(ctx, borrowRequest, staffId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBorrow provides a mock function with given fields: ctx, id, userId, role
+func (_m *BorrowUsecase) GetBorrow(ctx context.Context, id int, userId int, role string) (*dto.BorrowResponse, error) {
+	ret := _m.Called(ctx, id, userId, role)
+
+	var r0 *dto.BorrowResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, string) *dto.BorrowResponse); ok {
+		r0 = rf(ctx, id, userId, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BorrowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, string) error); ok {
+		r1 = rf(ctx, id, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBorrows provides a mock function with given fields: ctx, query
+func (_m *BorrowUsecase) ListBorrows(ctx context.Context, query *dto.BorrowListQuery) (*dto.BorrowPageResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 *dto.BorrowPageResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.BorrowListQuery) *dto.BorrowPageResponse); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BorrowPageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.BorrowListQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserBorrows provides a mock function with given fields: ctx, userId, query
+func (_m *BorrowUsecase) ListUserBorrows(ctx context.Context, userId int, query *dto.BorrowListQuery) (*dto.BorrowPageResponse, error) {
+	ret := _m.Called(ctx, userId, query)
+
+	var r0 *dto.BorrowPageResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, *dto.BorrowListQuery) *dto.BorrowPageResponse); ok {
+		r0 = rf(ctx, userId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BorrowPageResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *dto.BorrowListQuery) error); ok {
+		r1 = rf(ctx, userId, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Record provides a mock function with given fields: ctx, borrowRequest
 func (_m *BorrowUsecase) Record(ctx context.Context, borrowRequest *dto.BorrowRequest) (*dto.BorrowResponse, error) {
 	ret := _m.Called(ctx, borrowRequest)
@@ -37,6 +175,29 @@ func (_m *BorrowUsecase) Record(ctx context.Context, borrowRequest *dto.BorrowRe
 	return r0, r1
 }
 
+// Renew provides a mock function with given fields: ctx, id, userId
+func (_m *BorrowUsecase) Renew(ctx context.Context, id int, userId int) (*dto.BorrowResponse, error) {
+	ret := _m.Called(ctx, id, userId)
+
+	var r0 *dto.BorrowResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *dto.BorrowResponse); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BorrowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Return provides a mock function with given fields: ctx, returnRequest
 func (_m *BorrowUsecase) Return(ctx context.Context, returnRequest *dto.ReturnRequest) (*dto.BorrowResponse, error) {
 	ret := _m.Called(ctx, returnRequest)
@@ -60,6 +221,29 @@ func (_m *BorrowUsecase) Return(ctx context.Context, returnRequest *dto.ReturnRe
 	return r0, r1
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, id, staffId, statusRequest
+func (_m *BorrowUsecase) UpdateStatus(ctx context.Context, id int, staffId int, statusRequest *dto.BorrowStatusRequest) (*dto.BorrowResponse, error) {
+	ret := _m.Called(ctx, id, staffId, statusRequest)
+
+	var r0 *dto.BorrowResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *dto.BorrowStatusRequest) *dto.BorrowResponse); ok {
+		r0 = rf(ctx, id, staffId, statusRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.BorrowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *dto.BorrowStatusRequest) error); ok {
+		r1 = rf(ctx, id, staffId, statusRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewBorrowUsecase interface {
 	mock.TestingT
 	Cleanup(func())
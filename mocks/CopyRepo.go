@@ -0,0 +1,222 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "archive_lib/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CopyRepo is an autogenerated mock type for the CopyRepo type
+type CopyRepo struct {
+	mock.Mock
+}
+
+// AddCopy provides a mock function with given fields: ctx, bookCopy
+func (_m *CopyRepo) AddCopy(ctx context.Context, bookCopy *entity.BookCopy) (*entity.BookCopy, error) {
+	ret := _m.Called(ctx, bookCopy)
+
+	var r0 *entity.BookCopy
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookCopy) *entity.BookCopy); ok {
+		r0 = rf(ctx, bookCopy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookCopy) error); ok {
+		r1 = rf(ctx, bookCopy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCopyByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *CopyRepo) GetCopyByBarcode(ctx context.Context, barcode string) (*entity.BookCopy, error) {
+	ret := _m.Called(ctx, barcode)
+
+	var r0 *entity.BookCopy
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.BookCopy); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCopyById provides a mock function with given fields: ctx, id
+func (_m *CopyRepo) GetCopyById(ctx context.Context, id int) (*entity.BookCopy, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.BookCopy
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.BookCopy); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsBarcodeExisted provides a mock function with given fields: ctx, barcode
+func (_m *CopyRepo) IsBarcodeExisted(ctx context.Context, barcode string) (bool, error) {
+	ret := _m.Called(ctx, barcode)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsCopyExisted provides a mock function with given fields: ctx, id
+func (_m *CopyRepo) IsCopyExisted(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCopiesByBook provides a mock function with given fields: ctx, bookId
+func (_m *CopyRepo) ListCopiesByBook(ctx context.Context, bookId int) ([]entity.BookCopy, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 []entity.BookCopy
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.BookCopy); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCopyStatus provides a mock function with given fields: ctx, id, status
+func (_m *CopyRepo) SetCopyStatus(ctx context.Context, id int, status string) error {
+	ret := _m.Called(ctx, id, status)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TakeAvailableCopy provides a mock function with given fields: ctx, bookId
+func (_m *CopyRepo) TakeAvailableCopy(ctx context.Context, bookId int) (int, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCopy provides a mock function with given fields: ctx, bookCopy
+func (_m *CopyRepo) UpdateCopy(ctx context.Context, bookCopy *entity.BookCopy) (*entity.BookCopy, error) {
+	ret := _m.Called(ctx, bookCopy)
+
+	var r0 *entity.BookCopy
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BookCopy) *entity.BookCopy); ok {
+		r0 = rf(ctx, bookCopy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BookCopy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.BookCopy) error); ok {
+		r1 = rf(ctx, bookCopy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewCopyRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCopyRepo creates a new instance of CopyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCopyRepo(t mockConstructorTestingTNewCopyRepo) *CopyRepo {
+	mock := &CopyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "archive_lib/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CopyUsecase is an autogenerated mock type for the CopyUsecase type
+type CopyUsecase struct {
+	mock.Mock
+}
+
+// AddCopy provides a mock function with given fields: ctx, bookId, copyRequest
+func (_m *CopyUsecase) AddCopy(ctx context.Context, bookId int, copyRequest *dto.CopyRequest) (*dto.CopyResponse, error) {
+	ret := _m.Called(ctx, bookId, copyRequest)
+
+	var r0 *dto.CopyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, *dto.CopyRequest) *dto.CopyResponse); ok {
+		r0 = rf(ctx, bookId, copyRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.CopyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *dto.CopyRequest) error); ok {
+		r1 = rf(ctx, bookId, copyRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBookCopies provides a mock function with given fields: ctx, bookId
+func (_m *CopyUsecase) ListBookCopies(ctx context.Context, bookId int) ([]dto.CopyResponse, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 []dto.CopyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) []dto.CopyResponse); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.CopyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchCopy provides a mock function with given fields: ctx, id, copyPatchRequest
+func (_m *CopyUsecase) PatchCopy(ctx context.Context, id int, copyPatchRequest *dto.CopyPatchRequest) (*dto.CopyResponse, error) {
+	ret := _m.Called(ctx, id, copyPatchRequest)
+
+	var r0 *dto.CopyResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, *dto.CopyPatchRequest) *dto.CopyResponse); ok {
+		r0 = rf(ctx, id, copyPatchRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.CopyResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, *dto.CopyPatchRequest) error); ok {
+		r1 = rf(ctx, id, copyPatchRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewCopyUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCopyUsecase creates a new instance of CopyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCopyUsecase(t mockConstructorTestingTNewCopyUsecase) *CopyUsecase {
+	mock := &CopyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "archive_lib/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FineRepo is an autogenerated mock type for the FineRepo type
+type FineRepo struct {
+	mock.Mock
+}
+
+// AddFineEntry provides a mock function with given fields: ctx, entry
+func (_m *FineRepo) AddFineEntry(ctx context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+	ret := _m.Called(ctx, entry)
+
+	var r0 *entity.FineEntry
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.FineEntry) *entity.FineEntry); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.FineEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.FineEntry) error); ok {
+		r1 = rf(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: ctx, userId
+func (_m *FineRepo) GetBalance(ctx context.Context, userId int) (int64, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, int) int64); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFineEntries provides a mock function with given fields: ctx, userId
+func (_m *FineRepo) ListFineEntries(ctx context.Context, userId int) ([]entity.FineEntry, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []entity.FineEntry
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.FineEntry); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.FineEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewFineRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFineRepo creates a new instance of FineRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFineRepo(t mockConstructorTestingTNewFineRepo) *FineRepo {
+	mock := &FineRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
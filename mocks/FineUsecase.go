@@ -0,0 +1,99 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "archive_lib/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FineUsecase is an autogenerated mock type for the FineUsecase type
+type FineUsecase struct {
+	mock.Mock
+}
+
+// GetUserFines provides a mock function with given fields: ctx, userId
+func (_m *FineUsecase) GetUserFines(ctx context.Context, userId int) (*dto.FinesResponse, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 *dto.FinesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) *dto.FinesResponse); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.FinesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordPayment provides a mock function with given fields: ctx, userId, staffId, request
+func (_m *FineUsecase) RecordPayment(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error) {
+	ret := _m.Called(ctx, userId, staffId, request)
+
+	var r0 *dto.FineEntryResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *dto.FineSettlementRequest) *dto.FineEntryResponse); ok {
+		r0 = rf(ctx, userId, staffId, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.FineEntryResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *dto.FineSettlementRequest) error); ok {
+		r1 = rf(ctx, userId, staffId, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaiveFine provides a mock function with given fields: ctx, userId, staffId, request
+func (_m *FineUsecase) WaiveFine(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error) {
+	ret := _m.Called(ctx, userId, staffId, request)
+
+	var r0 *dto.FineEntryResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *dto.FineSettlementRequest) *dto.FineEntryResponse); ok {
+		r0 = rf(ctx, userId, staffId, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.FineEntryResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *dto.FineSettlementRequest) error); ok {
+		r1 = rf(ctx, userId, staffId, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewFineUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFineUsecase creates a new instance of FineUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFineUsecase(t mockConstructorTestingTNewFineUsecase) *FineUsecase {
+	mock := &FineUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,43 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthRepo is an autogenerated mock type for the HealthRepo type
+type HealthRepo struct {
+	mock.Mock
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *HealthRepo) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewHealthRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHealthRepo creates a new instance of HealthRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHealthRepo(t mockConstructorTestingTNewHealthRepo) *HealthRepo {
+	mock := &HealthRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "archive_lib/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthUsecase is an autogenerated mock type for the HealthUsecase type
+type HealthUsecase struct {
+	mock.Mock
+}
+
+// Ready provides a mock function with given fields: ctx
+func (_m *HealthUsecase) Ready(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartDraining provides a mock function with given fields:
+func (_m *HealthUsecase) StartDraining() {
+	_m.Called()
+}
+
+// Status provides a mock function with given fields: ctx
+func (_m *HealthUsecase) Status(ctx context.Context) *dto.StatusResponse {
+	ret := _m.Called(ctx)
+
+	var r0 *dto.StatusResponse
+	if rf, ok := ret.Get(0).(func(context.Context) *dto.StatusResponse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.StatusResponse)
+		}
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewHealthUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHealthUsecase creates a new instance of HealthUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHealthUsecase(t mockConstructorTestingTNewHealthUsecase) *HealthUsecase {
+	mock := &HealthUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,264 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "archive_lib/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HoldRepo is an autogenerated mock type for the HoldRepo type
+type HoldRepo struct {
+	mock.Mock
+}
+
+// AddHold provides a mock function with given fields: ctx, hold
+func (_m *HoldRepo) AddHold(ctx context.Context, hold *entity.Hold) (*entity.Hold, error) {
+	ret := _m.Called(ctx, hold)
+
+	var r0 *entity.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Hold) *entity.Hold); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Hold) error); ok {
+		r1 = rf(ctx, hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelHold provides a mock function with given fields: ctx, id
+func (_m *HoldRepo) CancelHold(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExpireReadyHolds provides a mock function with given fields: ctx, bookId
+func (_m *HoldRepo) ExpireReadyHolds(ctx context.Context, bookId int) ([]int, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(context.Context, int) []int); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FulfilHold provides a mock function with given fields: ctx, bookId, userId
+func (_m *HoldRepo) FulfilHold(ctx context.Context, bookId int, userId int) (int, error) {
+	ret := _m.Called(ctx, bookId, userId)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) int); ok {
+		r0 = rf(ctx, bookId, userId)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, bookId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHoldById provides a mock function with given fields: ctx, id
+func (_m *HoldRepo) GetHoldById(ctx context.Context, id int) (*entity.Hold, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.Hold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasWaitingHolds provides a mock function with given fields: ctx, bookId
+func (_m *HoldRepo) HasWaitingHolds(ctx context.Context, bookId int) (bool, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsActiveHoldExisted provides a mock function with given fields: ctx, bookId, userId
+func (_m *HoldRepo) IsActiveHoldExisted(ctx context.Context, bookId int, userId int) (bool, error) {
+	ret := _m.Called(ctx, bookId, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = rf(ctx, bookId, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, bookId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsHoldExisted provides a mock function with given fields: ctx, id
+func (_m *HoldRepo) IsHoldExisted(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHoldsByBook provides a mock function with given fields: ctx, bookId
+func (_m *HoldRepo) ListHoldsByBook(ctx context.Context, bookId int) ([]entity.Hold, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 []entity.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.Hold); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHoldsByUser provides a mock function with given fields: ctx, userId
+func (_m *HoldRepo) ListHoldsByUser(ctx context.Context, userId int) ([]entity.Hold, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []entity.Hold
+	if rf, ok := ret.Get(0).(func(context.Context, int) []entity.Hold); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Hold)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadyNextHold provides a mock function with given fields: ctx, bookId, copyId, pickupDays
+func (_m *HoldRepo) ReadyNextHold(ctx context.Context, bookId int, copyId int, pickupDays int) (bool, error) {
+	ret := _m.Called(ctx, bookId, copyId, pickupDays)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) bool); ok {
+		r0 = rf(ctx, bookId, copyId, pickupDays)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, bookId, copyId, pickupDays)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewHoldRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHoldRepo creates a new instance of HoldRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHoldRepo(t mockConstructorTestingTNewHoldRepo) *HoldRepo {
+	mock := &HoldRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,113 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "archive_lib/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HoldUsecase is an autogenerated mock type for the HoldUsecase type
+type HoldUsecase struct {
+	mock.Mock
+}
+
+// CancelHold provides a mock function with given fields: ctx, id, userId
+func (_m *HoldUsecase) CancelHold(ctx context.Context, id int, userId int) error {
+	ret := _m.Called(ctx, id, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListBookHolds provides a mock function with given fields: ctx, bookId
+func (_m *HoldUsecase) ListBookHolds(ctx context.Context, bookId int) ([]dto.HoldResponse, error) {
+	ret := _m.Called(ctx, bookId)
+
+	var r0 []dto.HoldResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) []dto.HoldResponse); ok {
+		r0 = rf(ctx, bookId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.HoldResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, bookId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserHolds provides a mock function with given fields: ctx, userId
+func (_m *HoldUsecase) ListUserHolds(ctx context.Context, userId int) ([]dto.HoldResponse, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []dto.HoldResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) []dto.HoldResponse); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.HoldResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PlaceHold provides a mock function with given fields: ctx, bookId, userId
+func (_m *HoldUsecase) PlaceHold(ctx context.Context, bookId int, userId int) (*dto.HoldResponse, error) {
+	ret := _m.Called(ctx, bookId, userId)
+
+	var r0 *dto.HoldResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *dto.HoldResponse); ok {
+		r0 = rf(ctx, bookId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.HoldResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, bookId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewHoldUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHoldUsecase creates a new instance of HoldUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHoldUsecase(t mockConstructorTestingTNewHoldUsecase) *HoldUsecase {
+	mock := &HoldUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
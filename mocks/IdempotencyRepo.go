@@ -0,0 +1,125 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "archive_lib/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdempotencyRepo is an autogenerated mock type for the IdempotencyRepo type
+type IdempotencyRepo struct {
+	mock.Mock
+}
+
+// GetKey provides a mock function with given fields: ctx, userId, key
+func (_m *IdempotencyRepo) GetKey(ctx context.Context, userId int, key string) (*entity.IdempotencyKey, error) {
+	ret := _m.Called(ctx, userId, key)
+
+	var r0 *entity.IdempotencyKey
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *entity.IdempotencyKey); ok {
+		r0 = rf(ctx, userId, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.IdempotencyKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userId, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeExpiredKeys provides a mock function with given fields: ctx
+func (_m *IdempotencyRepo) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseKey provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepo) ReleaseKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.IdempotencyKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveKey provides a mock function with given fields: ctx, key, ttl, lease
+func (_m *IdempotencyRepo) ReserveKey(ctx context.Context, key *entity.IdempotencyKey, ttl time.Duration, lease time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, ttl, lease)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.IdempotencyKey, time.Duration, time.Duration) int64); ok {
+		r0 = rf(ctx, key, ttl, lease)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.IdempotencyKey, time.Duration, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveResponse provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepo) SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.IdempotencyKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIdempotencyRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdempotencyRepo creates a new instance of IdempotencyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdempotencyRepo(t mockConstructorTestingTNewIdempotencyRepo) *IdempotencyRepo {
+	mock := &IdempotencyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
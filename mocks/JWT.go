@@ -9,20 +9,41 @@ type JWT struct {
 	mock.Mock
 }
 
-// GenerateJWT provides a mock function with given fields: userId
-func (_m *JWT) GenerateJWT(userId string) (string, error) {
-	ret := _m.Called(userId)
+// GenerateJWT provides a mock function with given fields: userId, role
+func (_m *JWT) GenerateJWT(userId string, role string) (string, error) {
+	ret := _m.Called(userId, role)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(userId)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(userId, role)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(userId)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateRefreshToken provides a mock function with given fields:
+func (_m *JWT) GenerateRefreshToken() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,53 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	migration "archive_lib/migration"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MigrationChecker is an autogenerated mock type for the MigrationChecker type
+type MigrationChecker struct {
+	mock.Mock
+}
+
+// Pending provides a mock function with given fields: ctx
+func (_m *MigrationChecker) Pending(ctx context.Context) ([]migration.Migration, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []migration.Migration
+	if rf, ok := ret.Get(0).(func(context.Context) []migration.Migration); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]migration.Migration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewMigrationChecker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMigrationChecker creates a new instance of MigrationChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMigrationChecker(t mockConstructorTestingTNewMigrationChecker) *MigrationChecker {
+	mock := &MigrationChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "archive_lib/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// NotificationRepo is an autogenerated mock type for the NotificationRepo type
+type NotificationRepo struct {
+	mock.Mock
+}
+
+// ClaimNotification provides a mock function with given fields: ctx, reminder, maxAttempts
+func (_m *NotificationRepo) ClaimNotification(ctx context.Context, reminder *entity.Reminder, maxAttempts int) (bool, error) {
+	ret := _m.Called(ctx, reminder, maxAttempts)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Reminder, int) bool); ok {
+		r0 = rf(ctx, reminder, maxAttempts)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Reminder, int) error); ok {
+		r1 = rf(ctx, reminder, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPendingReminders provides a mock function with given fields: ctx, dueSoonDays, maxAttempts, limit
+func (_m *NotificationRepo) ListPendingReminders(ctx context.Context, dueSoonDays int, maxAttempts int, limit int) ([]entity.Reminder, error) {
+	ret := _m.Called(ctx, dueSoonDays, maxAttempts, limit)
+
+	var r0 []entity.Reminder
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []entity.Reminder); ok {
+		r0 = rf(ctx, dueSoonDays, maxAttempts, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Reminder)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, dueSoonDays, maxAttempts, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkNotificationFailed provides a mock function with given fields: ctx, id, retryDelay, reason
+func (_m *NotificationRepo) MarkNotificationFailed(ctx context.Context, id int, retryDelay time.Duration, reason string) error {
+	ret := _m.Called(ctx, id, retryDelay, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration, string) error); ok {
+		r0 = rf(ctx, id, retryDelay, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkNotificationSent provides a mock function with given fields: ctx, id
+func (_m *NotificationRepo) MarkNotificationSent(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewNotificationRepo interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewNotificationRepo creates a new instance of NotificationRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewNotificationRepo(t mockConstructorTestingTNewNotificationRepo) *NotificationRepo {
+	mock := &NotificationRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -13,6 +13,20 @@ type TransactionRepo struct {
 	mock.Mock
 }
 
+// WithinSavepoint provides a mock function with given fields: ctx, fn
+func (_m *TransactionRepo) WithinSavepoint(ctx context.Context, fn func(context.Context) error) error {
+	ret := _m.Called(ctx, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WithinTransaction provides a mock function with given fields: ctx, fn
 func (_m *TransactionRepo) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
 	ret := _m.Called(ctx, fn)
@@ -14,6 +14,29 @@ type UserRepo struct {
 	mock.Mock
 }
 
+// AddUser provides a mock function with given fields: ctx, user
+func (_m *UserRepo) AddUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) *entity.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepo) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	ret := _m.Called(ctx, email)
@@ -37,6 +60,29 @@ func (_m *UserRepo) GetUserByEmail(ctx context.Context, email string) (*entity.U
 	return r0, r1
 }
 
+// GetUserById provides a mock function with given fields: ctx, id
+func (_m *UserRepo) GetUserById(ctx context.Context, id int) (*entity.User, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, int) *entity.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsEmailExisted provides a mock function with given fields: ctx, email
 func (_m *UserRepo) IsEmailExisted(ctx context.Context, email string) (bool, error) {
 	ret := _m.Called(ctx, email)
@@ -58,6 +104,48 @@ func (_m *UserRepo) IsEmailExisted(ctx context.Context, email string) (bool, err
 	return r0, r1
 }
 
+// IsUserExisted provides a mock function with given fields: ctx, id
+func (_m *UserRepo) IsUserExisted(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockUser provides a mock function with given fields: ctx, id
+func (_m *UserRepo) LockUser(ctx context.Context, id int) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserRepo interface {
 	mock.TestingT
 	Cleanup(func())
@@ -37,6 +37,66 @@ func (_m *UserUsecase) Login(ctx context.Context, authRequest *dto.AuthRequest)
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: ctx, logoutRequest
+func (_m *UserUsecase) Logout(ctx context.Context, logoutRequest *dto.LogoutRequest) error {
+	ret := _m.Called(ctx, logoutRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.LogoutRequest) error); ok {
+		r0 = rf(ctx, logoutRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Refresh provides a mock function with given fields: ctx, refreshRequest
+func (_m *UserUsecase) Refresh(ctx context.Context, refreshRequest *dto.RefreshRequest) (*dto.AuthResponse, error) {
+	ret := _m.Called(ctx, refreshRequest)
+
+	var r0 *dto.AuthResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.RefreshRequest) *dto.AuthResponse); ok {
+		r0 = rf(ctx, refreshRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.AuthResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.RefreshRequest) error); ok {
+		r1 = rf(ctx, refreshRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Register provides a mock function with given fields: ctx, registerRequest
+func (_m *UserUsecase) Register(ctx context.Context, registerRequest *dto.RegisterRequest) (*dto.UserResponse, error) {
+	ret := _m.Called(ctx, registerRequest)
+
+	var r0 *dto.UserResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.RegisterRequest) *dto.UserResponse); ok {
+		r0 = rf(ctx, registerRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.UserResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.RegisterRequest) error); ok {
+		r1 = rf(ctx, registerRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserUsecase interface {
 	mock.TestingT
 	Cleanup(func())
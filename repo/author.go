@@ -0,0 +1,141 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+)
+
+type AuthorRepo interface {
+	ListAuthors(ctx context.Context) ([]entity.Author, error)
+	GetAuthorById(ctx context.Context, id int) (*entity.Author, error)
+	AddAuthor(ctx context.Context, author *entity.Author) (*entity.Author, error)
+	UpdateAuthor(ctx context.Context, author *entity.Author) (*entity.Author, error)
+	DeleteAuthor(ctx context.Context, id int) (*entity.Author, error)
+	IsAuthorExisted(ctx context.Context, id int) (bool, error)
+	HasActiveBooks(ctx context.Context, id int) (bool, error)
+}
+
+type authorRepoImpl struct {
+	db *sql.DB
+}
+
+func NewAuthorRepo(db *sql.DB) authorRepoImpl {
+	return authorRepoImpl{
+		db: db,
+	}
+}
+
+func (repo authorRepoImpl) ListAuthors(ctx context.Context) ([]entity.Author, error) {
+	authors := []entity.Author{}
+	sql := `SELECT id, name FROM authors WHERE deleted_at IS NULL ORDER BY id;`
+
+	rows, err := repo.db.QueryContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var author entity.Author
+		err := rows.Scan(&author.Id, &author.Name)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, author)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+func (repo authorRepoImpl) GetAuthorById(ctx context.Context, id int) (*entity.Author, error) {
+	sql := `SELECT id, name FROM authors WHERE id = $1 AND deleted_at IS NULL;`
+
+	var author entity.Author
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(&author.Id, &author.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &author, nil
+}
+
+func (repo authorRepoImpl) AddAuthor(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	sql := `INSERT INTO authors (name) VALUES ($1) RETURNING id;`
+
+	err := repo.db.QueryRowContext(ctx, sql, author.Name).Scan(&author.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (repo authorRepoImpl) UpdateAuthor(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	sql := `UPDATE 
+				authors
+			SET 
+				name = $2, 
+				updated_at = NOW()
+			WHERE 
+				id = $1 AND deleted_at IS NULL
+			RETURNING 
+				id, name;`
+
+	var updated entity.Author
+	err := repo.db.QueryRowContext(ctx, sql, author.Id, author.Name).Scan(&updated.Id, &updated.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (repo authorRepoImpl) DeleteAuthor(ctx context.Context, id int) (*entity.Author, error) {
+	sql := `UPDATE 
+				authors
+			SET 
+				deleted_at = NOW(), 
+				updated_at = NOW()
+			WHERE 
+				id = $1 AND deleted_at IS NULL
+			RETURNING 
+				id, name;`
+
+	var author entity.Author
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(&author.Id, &author.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &author, nil
+}
+
+func (repo authorRepoImpl) IsAuthorExisted(ctx context.Context, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM authors WHERE id = $1 AND deleted_at IS NULL);`
+
+	var found bool
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(&found)
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo authorRepoImpl) HasActiveBooks(ctx context.Context, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM books WHERE author_id = $1 AND deleted_at IS NULL);`
+
+	var found bool
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(&found)
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
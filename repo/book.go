@@ -4,21 +4,44 @@ import (
 	"archive_lib/entity"
 	"context"
 	"database/sql"
+	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
 )
 
 type BookRepo interface {
 	ListBooks(ctx context.Context) ([]entity.Book, error)
 	GetBooksByTitle(ctx context.Context, title string) ([]entity.Book, error)
+	GetBooksByAuthorId(ctx context.Context, authorId int) ([]entity.Book, error)
+	ListBooksPage(ctx context.Context, query *entity.BookQuery) ([]entity.Book, error)
+	CountBooks(ctx context.Context, query *entity.BookQuery) (int, error)
+	SearchBooks(ctx context.Context, search *entity.BookSearch) ([]entity.BookSearchResult, error)
+	CountSearchBooks(ctx context.Context, search *entity.BookSearch) (int, error)
 	AddBook(ctx context.Context, bookPost *entity.BookPost) (*entity.Book, error)
+	GetBookById(ctx context.Context, id int) (*entity.Book, error)
+	UpdateBook(ctx context.Context, bookPost *entity.BookPost) (*entity.Book, error)
+	DeleteBook(ctx context.Context, id int) (*entity.Book, error)
+	RestoreBook(ctx context.Context, id int) (*entity.Book, error)
 	IsTitleExisted(ctx context.Context, title string) (bool, error)
+	IsTitleExistedForOther(ctx context.Context, title string, id int) (bool, error)
 	IsAuthorExisted(ctx context.Context, id int) (bool, error)
 	IsBookExisted(ctx context.Context, id int) (bool, error)
+	IsDeletedBookExisted(ctx context.Context, id int) (bool, error)
 	IsStockAvailable(ctx context.Context, id int) (bool, error)
-	DecrementStock(ctx context.Context, id int) error
-	IncrementStock(ctx context.Context, id int) error
 }
 
+// the quantity of a book is the number of its copies available on the shelf
+const availableCopiesColumn = `(SELECT COUNT(*) FROM book_copies c WHERE c.book_id = b.id AND c.status = 'available')::INT`
+
+var bookSortColumns = map[string]string{
+	"id":       "b.id",
+	"title":    "b.title",
+	"quantity": availableCopiesColumn,
+}
+
+var searchTermPattern = regexp.MustCompile(`"[^"]*"|\S+`)
+
 type bookRepoImpl struct {
 	db *sql.DB
 }
@@ -32,7 +55,7 @@ func NewBookRepo(db *sql.DB) bookRepoImpl {
 func (repo bookRepoImpl) ListBooks(ctx context.Context) ([]entity.Book, error) {
 	books := []entity.Book{}
 	sql := `SELECT 
-				b.id, b.author_id, a.name, b.title, b.description, b.quantity, b.cover
+				b.id, b.author_id, a.name, b.title, b.description, ` + availableCopiesColumn + `, b.cover
 			FROM 
 				books b JOIN authors a ON a.id = b.author_id 
 			WHERE 
@@ -75,7 +98,7 @@ func (repo bookRepoImpl) GetBooksByTitle(ctx context.Context, title string) ([]e
 	books := []entity.Book{}
 
 	sql := `SELECT 
-				b.id, b.author_id, a.name, b.title, b.description, b.quantity, b.cover
+				b.id, b.author_id, a.name, b.title, b.description, ` + availableCopiesColumn + `, b.cover
 			FROM 
 				books b JOIN authors a ON a.id = b.author_id 
 			WHERE 
@@ -114,6 +137,220 @@ func (repo bookRepoImpl) GetBooksByTitle(ctx context.Context, title string) ([]e
 	return books, nil
 }
 
+func (repo bookRepoImpl) GetBooksByAuthorId(ctx context.Context, authorId int) ([]entity.Book, error) {
+	books := []entity.Book{}
+
+	sql := `SELECT 
+				b.id, b.title, b.description, ` + availableCopiesColumn + `, b.cover
+			FROM 
+				books b
+			WHERE 
+				b.author_id = $1 AND b.deleted_at IS NULL
+			ORDER BY 
+				b.id`
+
+	rows, err := repo.db.QueryContext(ctx, sql, authorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book entity.Book
+		err := rows.Scan(
+			&book.Id,
+			&book.Title,
+			&book.Description,
+			&book.Quantity,
+			&book.Cover,
+		)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, book)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (repo bookRepoImpl) buildBookFilter(query *entity.BookQuery, inputs *[]any) string {
+	conditions := []string{"b.deleted_at IS NULL"}
+
+	if query.Title != "" {
+		*inputs = append(*inputs, "%"+query.Title+"%")
+		conditions = append(conditions, fmt.Sprintf("b.title ILIKE $%d", len(*inputs)))
+	}
+
+	if query.AuthorId != nil {
+		*inputs = append(*inputs, *query.AuthorId)
+		conditions = append(conditions, fmt.Sprintf("b.author_id = $%d", len(*inputs)))
+	}
+
+	if query.AuthorName != "" {
+		*inputs = append(*inputs, "%"+query.AuthorName+"%")
+		conditions = append(conditions, fmt.Sprintf("a.name ILIKE $%d", len(*inputs)))
+	}
+
+	if query.Available != nil {
+		if *query.Available {
+			conditions = append(conditions, availableCopiesColumn+" > 0")
+		} else {
+			conditions = append(conditions, availableCopiesColumn+" = 0")
+		}
+	}
+
+	if query.QuantityMin != nil {
+		*inputs = append(*inputs, *query.QuantityMin)
+		conditions = append(conditions, fmt.Sprintf("%s >= $%d", availableCopiesColumn, len(*inputs)))
+	}
+
+	if query.QuantityMax != nil {
+		*inputs = append(*inputs, *query.QuantityMax)
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", availableCopiesColumn, len(*inputs)))
+	}
+
+	if query.HasCover != nil {
+		if *query.HasCover {
+			conditions = append(conditions, "(b.cover IS NOT NULL AND b.cover <> '')")
+		} else {
+			conditions = append(conditions, "(b.cover IS NULL OR b.cover = '')")
+		}
+	}
+
+	if query.CreatedAfter != nil {
+		*inputs = append(*inputs, *query.CreatedAfter)
+		conditions = append(conditions, fmt.Sprintf("b.created_at >= $%d", len(*inputs)))
+	}
+
+	if query.CreatedBefore != nil {
+		*inputs = append(*inputs, *query.CreatedBefore)
+		conditions = append(conditions, fmt.Sprintf("b.created_at < $%d", len(*inputs)))
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+func (repo bookRepoImpl) buildCursorCondition(query *entity.BookQuery, column string, inputs *[]any) string {
+	operator := ">"
+	if query.SortDesc {
+		operator = "<"
+	}
+
+	cursor := query.Cursor
+	switch column {
+	case "b.title":
+		*inputs = append(*inputs, cursor.Title, cursor.Id)
+		return fmt.Sprintf("(b.title, b.id) %s ($%d, $%d)", operator, len(*inputs)-1, len(*inputs))
+	case availableCopiesColumn:
+		*inputs = append(*inputs, cursor.Quantity, cursor.Id)
+		return fmt.Sprintf("(%s, b.id) %s ($%d, $%d)", availableCopiesColumn, operator, len(*inputs)-1, len(*inputs))
+	default:
+		*inputs = append(*inputs, cursor.Id)
+		return fmt.Sprintf("b.id %s $%d", operator, len(*inputs))
+	}
+}
+
+func (repo bookRepoImpl) buildListBooksPageQuery(query *entity.BookQuery, inputs *[]any) string {
+	var sql strings.Builder
+
+	column, ok := bookSortColumns[query.SortBy]
+	if !ok {
+		column = bookSortColumns["id"]
+	}
+	direction := "ASC"
+	if query.SortDesc {
+		direction = "DESC"
+	}
+
+	sql.WriteString(`SELECT 
+				b.id, b.author_id, a.name, b.title, b.description, ` + availableCopiesColumn + `, b.cover
+			FROM 
+				books b JOIN authors a ON a.id = b.author_id 
+			WHERE `)
+	sql.WriteString(repo.buildBookFilter(query, inputs))
+
+	if query.Cursor != nil {
+		sql.WriteString(" AND ")
+		sql.WriteString(repo.buildCursorCondition(query, column, inputs))
+	}
+
+	sql.WriteString(" ORDER BY ")
+	if column != "b.id" {
+		sql.WriteString(column + " " + direction + ", ")
+	}
+	sql.WriteString("b.id " + direction)
+
+	*inputs = append(*inputs, query.Limit)
+	sql.WriteString(fmt.Sprintf(" LIMIT $%d", len(*inputs)))
+
+	if query.Offset > 0 {
+		*inputs = append(*inputs, query.Offset)
+		sql.WriteString(fmt.Sprintf(" OFFSET $%d", len(*inputs)))
+	}
+
+	return sql.String()
+}
+
+func (repo bookRepoImpl) ListBooksPage(ctx context.Context, query *entity.BookQuery) ([]entity.Book, error) {
+	books := []entity.Book{}
+	inputs := make([]any, 0)
+
+	sql := repo.buildListBooksPageQuery(query, &inputs)
+	rows, err := repo.db.QueryContext(ctx, sql, inputs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var book entity.Book
+		var author entity.Author
+		err := rows.Scan(
+			&book.Id,
+			&author.Id,
+			&author.Name,
+			&book.Title,
+			&book.Description,
+			&book.Quantity,
+			&book.Cover,
+		)
+		if err != nil {
+			return nil, err
+		}
+		book.Author = &author
+		books = append(books, book)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (repo bookRepoImpl) CountBooks(ctx context.Context, query *entity.BookQuery) (int, error) {
+	inputs := make([]any, 0)
+	sql := `SELECT 
+				COUNT(*)
+			FROM 
+				books b JOIN authors a ON a.id = b.author_id 
+			WHERE ` + repo.buildBookFilter(query, &inputs)
+
+	var total int
+	err := repo.db.QueryRowContext(ctx, sql, inputs...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
 func (repo bookRepoImpl) IsTitleExisted(ctx context.Context, title string) (bool, error) {
 	sql := `SELECT EXISTS(SELECT 1 FROM books WHERE title = $1);`
 
@@ -126,12 +363,31 @@ func (repo bookRepoImpl) IsTitleExisted(ctx context.Context, title string) (bool
 	return found, nil
 }
 
+func (repo bookRepoImpl) IsTitleExistedForOther(ctx context.Context, title string, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM books WHERE title = $1 AND id <> $2);`
+
+	var found bool
+	err := repo.db.QueryRowContext(ctx, sql, title, id).Scan(&found)
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
 func (repo bookRepoImpl) buildAddBookQuery(book *entity.BookPost, inputs *[]any) string {
 	var query strings.Builder
 
-	columns := `INSERT INTO books (title, author_id, description, quantity`
-	values := `) VALUES ($1, $2, $3, $4`
-	returning := `) RETURNING id`
+	// the book is added together with its initial copies, barcoded BK<book id>-<copy number>
+	columns := `WITH book AS (INSERT INTO books (title, author_id, description`
+	values := `) VALUES ($1, $2, $3`
+	returning := `) RETURNING id), 
+			copies AS (
+				INSERT INTO book_copies (book_id, barcode) 
+				SELECT book.id, 'BK' || LPAD(book.id::TEXT, 6, '0') || '-' || LPAD(n::TEXT, 3, '0') 
+				FROM book, generate_series(1, $4::INT) n
+			) 
+			SELECT id FROM book`
 
 	if book.Cover != nil {
 		columns += ", cover"
@@ -147,7 +403,7 @@ func (repo bookRepoImpl) buildAddBookQuery(book *entity.BookPost, inputs *[]any)
 }
 
 func (repo bookRepoImpl) IsAuthorExisted(ctx context.Context, id int) (bool, error) {
-	sql := `SELECT EXISTS(SELECT 1 FROM authors WHERE id = $1);`
+	sql := `SELECT EXISTS(SELECT 1 FROM authors WHERE id = $1 AND deleted_at IS NULL);`
 
 	var found bool
 	err := repo.db.QueryRowContext(ctx, sql, id).Scan(&found)
@@ -172,7 +428,7 @@ func (repo bookRepoImpl) AddBook(ctx context.Context, bookPost *entity.BookPost)
 }
 
 func (repo bookRepoImpl) IsBookExisted(ctx context.Context, id int) (bool, error) {
-	sql := `SELECT EXISTS(SELECT 1 FROM books WHERE id = $1) FOR UPDATE;`
+	sql := `SELECT EXISTS(SELECT 1 FROM books WHERE id = $1 AND deleted_at IS NULL) FOR UPDATE;`
 
 	tx := extractTx(ctx)
 	var err error
@@ -192,69 +448,252 @@ func (repo bookRepoImpl) IsBookExisted(ctx context.Context, id int) (bool, error
 }
 
 func (repo bookRepoImpl) IsStockAvailable(ctx context.Context, id int) (bool, error) {
-	sql := `SELECT quantity FROM books WHERE id = $1;`
+	sql := `SELECT EXISTS(SELECT 1 FROM book_copies WHERE book_id = $1 AND status = 'available');`
 
 	tx := extractTx(ctx)
 	var err error
-	var stock int
+	var available bool
 
 	if tx != nil {
-		err = tx.QueryRowContext(ctx, sql, id).Scan(&stock)
+		err = tx.QueryRowContext(ctx, sql, id).Scan(&available)
 	} else {
-		err = repo.db.QueryRowContext(ctx, sql, id).Scan(&stock)
+		err = repo.db.QueryRowContext(ctx, sql, id).Scan(&available)
+	}
+
+	if err != nil {
+		return false, err
 	}
 
+	return available, nil
+}
+
+func (repo bookRepoImpl) IsDeletedBookExisted(ctx context.Context, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM books WHERE id = $1 AND deleted_at IS NOT NULL);`
+
+	var found bool
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(&found)
 	if err != nil {
 		return false, err
 	}
 
-	return (stock > 0), nil
+	return found, nil
+}
+
+func (repo bookRepoImpl) GetBookById(ctx context.Context, id int) (*entity.Book, error) {
+	sql := `SELECT 
+				b.id, b.author_id, a.name, b.title, b.description, ` + availableCopiesColumn + `, b.cover
+			FROM 
+				books b JOIN authors a ON a.id = b.author_id 
+			WHERE 
+				b.id = $1 AND b.deleted_at IS NULL;`
+
+	var book entity.Book
+	var author entity.Author
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(
+		&book.Id,
+		&author.Id,
+		&author.Name,
+		&book.Title,
+		&book.Description,
+		&book.Quantity,
+		&book.Cover,
+	)
+	if err != nil {
+		return nil, err
+	}
+	book.Author = &author
+
+	return &book, nil
 }
 
-func (repo bookRepoImpl) DecrementStock(ctx context.Context, id int) error {
+func (repo bookRepoImpl) UpdateBook(ctx context.Context, bookPost *entity.BookPost) (*entity.Book, error) {
 	sql := `UPDATE 
-				books
+				books b
 			SET 
-				quantity = quantity - 1, 
+				title = $2, 
+				author_id = $3, 
+				description = $4, 
+				cover = $5, 
 				updated_at = NOW()
 			WHERE 
-				id=$1`
-
-	tx := extractTx(ctx)
-	var err error
-	if tx != nil {
-		_, err = tx.ExecContext(ctx, sql, id)
-	} else {
-		_, err = repo.db.ExecContext(ctx, sql, id)
+				b.id = $1 AND b.deleted_at IS NULL
+			RETURNING 
+				b.id, ` + availableCopiesColumn + `;`
+
+	err := repo.db.QueryRowContext(
+		ctx,
+		sql,
+		bookPost.Id,
+		bookPost.Title,
+		bookPost.AuthorId,
+		bookPost.Description,
+		bookPost.Cover,
+	).Scan(&bookPost.Id, &bookPost.Quantity)
+	if err != nil {
+		return nil, err
 	}
 
+	return bookPost.ConvertToBook(), nil
+}
+
+func (repo bookRepoImpl) DeleteBook(ctx context.Context, id int) (*entity.Book, error) {
+	sql := `UPDATE 
+				books b
+			SET 
+				deleted_at = NOW(), 
+				updated_at = NOW()
+			WHERE 
+				b.id = $1 AND b.deleted_at IS NULL
+			RETURNING 
+				b.id, b.title, b.description, ` + availableCopiesColumn + `, b.cover;`
+
+	var book entity.Book
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(
+		&book.Id,
+		&book.Title,
+		&book.Description,
+		&book.Quantity,
+		&book.Cover,
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &book, nil
 }
 
-func (repo bookRepoImpl) IncrementStock(ctx context.Context, id int) error {
+func (repo bookRepoImpl) RestoreBook(ctx context.Context, id int) (*entity.Book, error) {
 	sql := `UPDATE 
-				books
+				books b
 			SET 
-				quantity = quantity + 1, 
+				deleted_at = NULL, 
 				updated_at = NOW()
 			WHERE 
-				id=$1`
+				b.id = $1 AND b.deleted_at IS NOT NULL
+			RETURNING 
+				b.id, b.title, b.description, ` + availableCopiesColumn + `, b.cover;`
+
+	var book entity.Book
+	err := repo.db.QueryRowContext(ctx, sql, id).Scan(
+		&book.Id,
+		&book.Title,
+		&book.Description,
+		&book.Quantity,
+		&book.Cover,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	tx := extractTx(ctx)
-	var err error
-	if tx != nil {
-		_, err = tx.ExecContext(ctx, sql, id)
-	} else {
-		_, err = repo.db.ExecContext(ctx, sql, id)
+	return &book, nil
+}
+
+func (repo bookRepoImpl) splitLexemes(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// buildTsQuery turns user input into to_tsquery syntax: quoted text becomes a
+// phrase, a trailing * marks a prefix, and every term must match.
+func (repo bookRepoImpl) buildTsQuery(text string) string {
+	terms := []string{}
+
+	for _, token := range searchTermPattern.FindAllString(text, -1) {
+		if strings.HasPrefix(token, `"`) {
+			lexemes := repo.splitLexemes(token)
+			if len(lexemes) > 0 {
+				terms = append(terms, "("+strings.Join(lexemes, " <-> ")+")")
+			}
+			continue
+		}
+
+		lexemes := repo.splitLexemes(token)
+		if len(lexemes) > 0 && strings.HasSuffix(token, "*") {
+			lexemes[len(lexemes)-1] += ":*"
+		}
+		terms = append(terms, lexemes...)
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+func (repo bookRepoImpl) SearchBooks(ctx context.Context, search *entity.BookSearch) ([]entity.BookSearchResult, error) {
+	results := []entity.BookSearchResult{}
+
+	tsQuery := repo.buildTsQuery(search.Query)
+	if tsQuery == "" {
+		return results, nil
 	}
 
+	sql := `SELECT 
+				b.id, b.author_id, a.name, b.title, b.description, ` + availableCopiesColumn + `, b.cover,
+				ts_rank_cd(b.search_vector, q.query) AS rank,
+				ts_headline('english', b.title, q.query, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>'),
+				ts_headline('english', b.description, q.query, 'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15')
+			FROM 
+				books b JOIN authors a ON a.id = b.author_id, 
+				to_tsquery('english', $1) q(query)
+			WHERE 
+				b.deleted_at IS NULL AND b.search_vector @@ q.query
+			ORDER BY 
+				rank DESC, b.id
+			LIMIT $2 OFFSET $3`
+
+	rows, err := repo.db.QueryContext(ctx, sql, tsQuery, search.Limit, search.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result entity.BookSearchResult
+		var author entity.Author
+		err := rows.Scan(
+			&result.Id,
+			&author.Id,
+			&author.Name,
+			&result.Title,
+			&result.Description,
+			&result.Quantity,
+			&result.Cover,
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.DescriptionHighlight,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result.Author = &author
+		results = append(results, result)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (repo bookRepoImpl) CountSearchBooks(ctx context.Context, search *entity.BookSearch) (int, error) {
+	tsQuery := repo.buildTsQuery(search.Query)
+	if tsQuery == "" {
+		return 0, nil
+	}
+
+	sql := `SELECT 
+				COUNT(*)
+			FROM 
+				books b
+			WHERE 
+				b.deleted_at IS NULL AND b.search_vector @@ to_tsquery('english', $1)`
+
+	var total int
+	err := repo.db.QueryRowContext(ctx, sql, tsQuery).Scan(&total)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return total, nil
 }
@@ -4,15 +4,35 @@ import (
 	"archive_lib/entity"
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// a loan still out after its due date is reported as overdue
+const (
+	borrowStatusColumn = `CASE WHEN status = 'borrowed' AND due_date < LOCALTIMESTAMP THEN 'overdue' ELSE status END`
+	daysOverdueColumn  = `GREATEST(0, CEIL(EXTRACT(EPOCH FROM COALESCE(returning_date, LOCALTIMESTAMP) - due_date) / 86400))::INT`
 )
 
 type BorrowRepo interface {
-	Record(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error)
+	ListBorrows(ctx context.Context, query *entity.BorrowQuery) ([]entity.Borrow, error)
+	CountBorrows(ctx context.Context, query *entity.BorrowQuery) (int, error)
+	Record(ctx context.Context, borrow *entity.Borrow, loanDays int) (*entity.Borrow, error)
 	Return(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error)
+	GetBorrowById(ctx context.Context, id int) (*entity.Borrow, error)
+	Renew(ctx context.Context, borrow *entity.Borrow, loanDays int) (*entity.Borrow, error)
+	AddRenewal(ctx context.Context, renewal *entity.Renewal) error
+	CountActiveBorrows(ctx context.Context, userId int) (int, error)
+	HasOverdueBorrows(ctx context.Context, userId int) (bool, error)
+	HasActiveBorrow(ctx context.Context, userId int, bookId int) (bool, error)
 	GetBookByBorrowId(ctx context.Context, id int) (int, error)
+	GetActiveBorrowIdByCopy(ctx context.Context, copyId int) (int, error)
+	HasUnresolvedBorrowByCopy(ctx context.Context, copyId int) (bool, error)
 	IsUserAuthorized(ctx context.Context, record_id int, user_id int) (bool, error)
 	IsBorrowExisted(ctx context.Context, id int) (bool, error)
 	IsReturned(ctx context.Context, id int) (bool, error)
+	UpdateStatus(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error)
 }
 
 type borrowRepoImpl struct {
@@ -25,21 +45,132 @@ func NewBorrowRepo(db *sql.DB) borrowRepoImpl {
 	}
 }
 
-func (repo borrowRepoImpl) Record(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error) {
+func (repo borrowRepoImpl) buildBorrowFilter(query *entity.BorrowQuery, inputs *[]any) string {
+	conditions := []string{"deleted_at IS NULL"}
+
+	if query.UserId != nil {
+		*inputs = append(*inputs, *query.UserId)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(*inputs)))
+	}
+
+	if query.BookId != nil {
+		*inputs = append(*inputs, *query.BookId)
+		conditions = append(conditions, fmt.Sprintf("book_id = $%d", len(*inputs)))
+	}
+
+	switch query.Status {
+	case entity.BorrowStatusOverdue:
+		conditions = append(conditions, "status = 'borrowed' AND due_date < LOCALTIMESTAMP")
+	case entity.BorrowStatusBorrowed:
+		conditions = append(conditions, "status = 'borrowed' AND due_date >= LOCALTIMESTAMP")
+	case entity.BorrowStatusReturned, entity.BorrowStatusLost, entity.BorrowStatusDamagedOnReturn, entity.BorrowStatusClaimedReturned:
+		*inputs = append(*inputs, query.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(*inputs)))
+	}
+
+	if query.BorrowedAfter != nil {
+		*inputs = append(*inputs, *query.BorrowedAfter)
+		conditions = append(conditions, fmt.Sprintf("borrowing_date >= $%d", len(*inputs)))
+	}
+
+	if query.BorrowedBefore != nil {
+		*inputs = append(*inputs, *query.BorrowedBefore)
+		conditions = append(conditions, fmt.Sprintf("borrowing_date < $%d", len(*inputs)))
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+func (repo borrowRepoImpl) ListBorrows(ctx context.Context, query *entity.BorrowQuery) ([]entity.Borrow, error) {
+	inputs := make([]any, 0)
+	sql := `SELECT 
+				id, user_id, book_id, copy_id, ` + borrowStatusColumn + `, borrowing_date, returning_date, due_date, ` + daysOverdueColumn + `, renewal_count, checked_out_by 
+			FROM 
+				borrowing_records 
+			WHERE ` + repo.buildBorrowFilter(query, &inputs) + `
+			ORDER BY borrowing_date DESC, id DESC`
+
+	if query.Limit > 0 {
+		inputs = append(inputs, query.Limit)
+		sql += fmt.Sprintf(" LIMIT $%d", len(inputs))
+	}
+	if query.Offset > 0 {
+		inputs = append(inputs, query.Offset)
+		sql += fmt.Sprintf(" OFFSET $%d", len(inputs))
+	}
+
+	rows, err := repo.db.QueryContext(ctx, sql, inputs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	borrows := []entity.Borrow{}
+	for rows.Next() {
+		var borrow entity.Borrow
+		var returningDate *time.Time
+		err := rows.Scan(
+			&borrow.Id,
+			&borrow.UserId,
+			&borrow.BookId,
+			&borrow.CopyId,
+			&borrow.Status,
+			&borrow.BorrowingDate,
+			&returningDate,
+			&borrow.DueDate,
+			&borrow.DaysOverdue,
+			&borrow.RenewalCount,
+			&borrow.CheckedOutBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if returningDate != nil {
+			borrow.ReturningDate = *returningDate
+		}
+		borrows = append(borrows, borrow)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return borrows, nil
+}
+
+func (repo borrowRepoImpl) CountBorrows(ctx context.Context, query *entity.BorrowQuery) (int, error) {
+	inputs := make([]any, 0)
+	sql := `SELECT 
+				COUNT(*) 
+			FROM 
+				borrowing_records 
+			WHERE ` + repo.buildBorrowFilter(query, &inputs)
+
+	var total int
+	err := repo.db.QueryRowContext(ctx, sql, inputs...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (repo borrowRepoImpl) Record(ctx context.Context, borrow *entity.Borrow, loanDays int) (*entity.Borrow, error) {
 	const status = "borrowed"
 	sql := `INSERT INTO 
-				borrowing_records (user_id, book_id, status, borrowing_date) 
+				borrowing_records (user_id, book_id, copy_id, status, borrowing_date, due_date, checked_out_by) 
 			VALUES 
-				($1, $2, $3, NOW()) 
+				($1, $2, $3, $4, NOW(), NOW() + make_interval(days => $5), $6) 
 			RETURNING 
-				id, status, borrowing_date`
+				id, status, borrowing_date, due_date`
 
 	tx := extractTx(ctx)
 	var err error
 	if tx != nil {
-		err = tx.QueryRowContext(ctx, sql, borrow.UserId, borrow.BookId, status).Scan(&borrow.Id, &borrow.Status, &borrow.BorrowingDate)
+		err = tx.QueryRowContext(ctx, sql, borrow.UserId, borrow.BookId, borrow.CopyId, status, loanDays, borrow.CheckedOutBy).Scan(&borrow.Id, &borrow.Status, &borrow.BorrowingDate, &borrow.DueDate)
 	} else {
-		err = repo.db.QueryRowContext(ctx, sql, borrow.UserId, borrow.BookId, status).Scan(&borrow.Id, &borrow.Status, &borrow.BorrowingDate)
+		err = repo.db.QueryRowContext(ctx, sql, borrow.UserId, borrow.BookId, borrow.CopyId, status, loanDays, borrow.CheckedOutBy).Scan(&borrow.Id, &borrow.Status, &borrow.BorrowingDate, &borrow.DueDate)
 	}
 
 	if err != nil {
@@ -49,6 +180,53 @@ func (repo borrowRepoImpl) Record(ctx context.Context, borrow *entity.Borrow) (*
 	return borrow, nil
 }
 
+// GetActiveBorrowIdByCopy returns the loan the copy is out on, or 0 when it is on the shelf.
+func (repo borrowRepoImpl) GetActiveBorrowIdByCopy(ctx context.Context, copyId int) (int, error) {
+	sql := `SELECT COALESCE((
+				SELECT id FROM borrowing_records 
+				WHERE copy_id = $1 AND returning_date IS NULL AND deleted_at IS NULL
+			), 0);`
+
+	tx := extractTx(ctx)
+	var err error
+	var id int
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, copyId).Scan(&id)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, copyId).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// HasUnresolvedBorrowByCopy reports whether a lost or claimed returned loan still points at the copy.
+func (repo borrowRepoImpl) HasUnresolvedBorrowByCopy(ctx context.Context, copyId int) (bool, error) {
+	sql := `SELECT EXISTS(
+				SELECT 1 FROM borrowing_records 
+				WHERE copy_id = $1 AND status IN ('lost', 'claimed_returned') AND deleted_at IS NULL
+			);`
+
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, copyId).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, copyId).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
 func (repo borrowRepoImpl) IsBorrowExisted(ctx context.Context, id int) (bool, error) {
 	sql := `SELECT EXISTS(SELECT 1 FROM borrowing_records WHERE id = $1);`
 
@@ -140,23 +318,31 @@ func (repo borrowRepoImpl) Return(ctx context.Context, borrow *entity.Borrow) (*
 			WHERE 
 				id = $1 AND user_id = $2
 			RETURNING 
-				book_id, status, borrowing_date, returning_date;`
+				book_id, copy_id, status, borrowing_date, returning_date, due_date, ` + daysOverdueColumn + `, renewal_count;`
 
 	tx := extractTx(ctx)
 	var err error
 	if tx != nil {
 		err = tx.QueryRowContext(ctx, sql, borrow.Id, borrow.UserId, status).Scan(
 			&borrow.BookId,
+			&borrow.CopyId,
 			&borrow.Status,
 			&borrow.BorrowingDate,
 			&borrow.ReturningDate,
+			&borrow.DueDate,
+			&borrow.DaysOverdue,
+			&borrow.RenewalCount,
 		)
 	} else {
 		err = repo.db.QueryRowContext(ctx, sql, borrow.Id, borrow.UserId, status).Scan(
 			&borrow.BookId,
+			&borrow.CopyId,
 			&borrow.Status,
 			&borrow.BorrowingDate,
 			&borrow.ReturningDate,
+			&borrow.DueDate,
+			&borrow.DaysOverdue,
+			&borrow.RenewalCount,
 		)
 	}
 
@@ -166,3 +352,204 @@ func (repo borrowRepoImpl) Return(ctx context.Context, borrow *entity.Borrow) (*
 
 	return borrow, nil
 }
+
+func (repo borrowRepoImpl) GetBorrowById(ctx context.Context, id int) (*entity.Borrow, error) {
+	sql := `SELECT 
+				user_id, book_id, copy_id, ` + borrowStatusColumn + `, borrowing_date, returning_date, due_date, ` + daysOverdueColumn + `, renewal_count, checked_out_by 
+			FROM 
+				borrowing_records 
+			WHERE 
+				id = $1 AND deleted_at IS NULL 
+			FOR UPDATE;`
+
+	tx := extractTx(ctx)
+	var err error
+	var returningDate *time.Time
+	borrow := entity.Borrow{Id: id}
+	dest := []any{
+		&borrow.UserId,
+		&borrow.BookId,
+		&borrow.CopyId,
+		&borrow.Status,
+		&borrow.BorrowingDate,
+		&returningDate,
+		&borrow.DueDate,
+		&borrow.DaysOverdue,
+		&borrow.RenewalCount,
+		&borrow.CheckedOutBy,
+	}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, id).Scan(dest...)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, id).Scan(dest...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if returningDate != nil {
+		borrow.ReturningDate = *returningDate
+	}
+
+	return &borrow, nil
+}
+
+func (repo borrowRepoImpl) Renew(ctx context.Context, borrow *entity.Borrow, loanDays int) (*entity.Borrow, error) {
+	sql := `UPDATE 
+				borrowing_records 
+			SET 
+				due_date = GREATEST(due_date, LOCALTIMESTAMP) + make_interval(days => $3), 
+				renewal_count = renewal_count + 1, 
+				updated_at = NOW() 
+			WHERE 
+				id = $1 AND user_id = $2 AND status = 'borrowed' 
+			RETURNING 
+				book_id, copy_id, status, borrowing_date, due_date, renewal_count;`
+
+	tx := extractTx(ctx)
+	var err error
+	dest := []any{&borrow.BookId, &borrow.CopyId, &borrow.Status, &borrow.BorrowingDate, &borrow.DueDate, &borrow.RenewalCount}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, borrow.Id, borrow.UserId, loanDays).Scan(dest...)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, borrow.Id, borrow.UserId, loanDays).Scan(dest...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return borrow, nil
+}
+
+func (repo borrowRepoImpl) AddRenewal(ctx context.Context, renewal *entity.Renewal) error {
+	sql := `INSERT INTO 
+				loan_renewals (borrowing_record_id, previous_due_date, new_due_date) 
+			VALUES 
+				($1, $2, $3) 
+			RETURNING 
+				id;`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, renewal.BorrowId, renewal.PreviousDueDate, renewal.NewDueDate).Scan(&renewal.Id)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, renewal.BorrowId, renewal.PreviousDueDate, renewal.NewDueDate).Scan(&renewal.Id)
+	}
+
+	return err
+}
+
+func (repo borrowRepoImpl) CountActiveBorrows(ctx context.Context, userId int) (int, error) {
+	sql := `SELECT COUNT(*) FROM borrowing_records WHERE user_id = $1 AND returning_date IS NULL AND deleted_at IS NULL;`
+
+	tx := extractTx(ctx)
+	var err error
+	var count int
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, userId).Scan(&count)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, userId).Scan(&count)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo borrowRepoImpl) HasOverdueBorrows(ctx context.Context, userId int) (bool, error) {
+	sql := `SELECT EXISTS(
+				SELECT 1 FROM borrowing_records 
+				WHERE user_id = $1 AND status = 'borrowed' AND due_date < LOCALTIMESTAMP AND deleted_at IS NULL
+			);`
+
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, userId).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, userId).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo borrowRepoImpl) HasActiveBorrow(ctx context.Context, userId int, bookId int) (bool, error) {
+	sql := `SELECT EXISTS(
+				SELECT 1 FROM borrowing_records 
+				WHERE user_id = $1 AND book_id = $2 AND returning_date IS NULL AND deleted_at IS NULL
+			);`
+
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, userId, bookId).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, userId, bookId).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+// UpdateStatus moves the record to borrow.Status, stamping the returning date when the status closes the loan.
+func (repo borrowRepoImpl) UpdateStatus(ctx context.Context, borrow *entity.Borrow) (*entity.Borrow, error) {
+	sql := `UPDATE 
+				borrowing_records 
+			SET 
+				status = $2, 
+				returning_date = CASE WHEN $3 THEN NOW() ELSE NULL END, 
+				updated_at = NOW() 
+			WHERE 
+				id = $1 
+			RETURNING 
+				user_id, book_id, copy_id, status, borrowing_date, returning_date, due_date, ` + daysOverdueColumn + `, renewal_count;`
+
+	tx := extractTx(ctx)
+	var err error
+	var returningDate *time.Time
+	dest := []any{
+		&borrow.UserId,
+		&borrow.BookId,
+		&borrow.CopyId,
+		&borrow.Status,
+		&borrow.BorrowingDate,
+		&returningDate,
+		&borrow.DueDate,
+		&borrow.DaysOverdue,
+		&borrow.RenewalCount,
+	}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, borrow.Id, borrow.Status, entity.ClosesLoan(borrow.Status)).Scan(dest...)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, borrow.Id, borrow.Status, entity.ClosesLoan(borrow.Status)).Scan(dest...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	borrow.ReturningDate = time.Time{}
+	if returningDate != nil {
+		borrow.ReturningDate = *returningDate
+	}
+
+	return borrow, nil
+}
@@ -0,0 +1,210 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+)
+
+type CopyRepo interface {
+	AddCopy(ctx context.Context, bookCopy *entity.BookCopy) (*entity.BookCopy, error)
+	ListCopiesByBook(ctx context.Context, bookId int) ([]entity.BookCopy, error)
+	IsCopyExisted(ctx context.Context, id int) (bool, error)
+	GetCopyById(ctx context.Context, id int) (*entity.BookCopy, error)
+	IsBarcodeExisted(ctx context.Context, barcode string) (bool, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (*entity.BookCopy, error)
+	UpdateCopy(ctx context.Context, bookCopy *entity.BookCopy) (*entity.BookCopy, error)
+	TakeAvailableCopy(ctx context.Context, bookId int) (int, error)
+	SetCopyStatus(ctx context.Context, id int, status string) error
+}
+
+type copyRepoImpl struct {
+	db *sql.DB
+}
+
+func NewCopyRepo(db *sql.DB) copyRepoImpl {
+	return copyRepoImpl{
+		db: db,
+	}
+}
+
+func (repo copyRepoImpl) queryRow(ctx context.Context, sql string, args ...any) *sql.Row {
+	tx := extractTx(ctx)
+	if tx != nil {
+		return tx.QueryRowContext(ctx, sql, args...)
+	}
+	return repo.db.QueryRowContext(ctx, sql, args...)
+}
+
+func (repo copyRepoImpl) AddCopy(ctx context.Context, bookCopy *entity.BookCopy) (*entity.BookCopy, error) {
+	sql := `INSERT INTO 
+				book_copies (book_id, barcode, condition, location, status) 
+			VALUES 
+				($1, $2, $3, $4, 'available') 
+			RETURNING 
+				id, status`
+
+	err := repo.queryRow(ctx, sql, bookCopy.BookId, bookCopy.Barcode, bookCopy.Condition, bookCopy.Location).Scan(&bookCopy.Id, &bookCopy.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (repo copyRepoImpl) ListCopiesByBook(ctx context.Context, bookId int) ([]entity.BookCopy, error) {
+	sql := `SELECT 
+				id, book_id, barcode, condition, location, status 
+			FROM 
+				book_copies 
+			WHERE 
+				book_id = $1 
+			ORDER BY 
+				id;`
+
+	rows, err := repo.db.QueryContext(ctx, sql, bookId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	copies := []entity.BookCopy{}
+	for rows.Next() {
+		var bookCopy entity.BookCopy
+		err := rows.Scan(&bookCopy.Id, &bookCopy.BookId, &bookCopy.Barcode, &bookCopy.Condition, &bookCopy.Location, &bookCopy.Status)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, bookCopy)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+func (repo copyRepoImpl) IsCopyExisted(ctx context.Context, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM book_copies WHERE id = $1);`
+
+	var found bool
+	err := repo.queryRow(ctx, sql, id).Scan(&found)
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo copyRepoImpl) GetCopyById(ctx context.Context, id int) (*entity.BookCopy, error) {
+	sql := `SELECT 
+				id, book_id, barcode, condition, location, status 
+			FROM 
+				book_copies 
+			WHERE 
+				id = $1 
+			FOR UPDATE;`
+
+	var bookCopy entity.BookCopy
+	err := repo.queryRow(ctx, sql, id).Scan(&bookCopy.Id, &bookCopy.BookId, &bookCopy.Barcode, &bookCopy.Condition, &bookCopy.Location, &bookCopy.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookCopy, nil
+}
+
+func (repo copyRepoImpl) IsBarcodeExisted(ctx context.Context, barcode string) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM book_copies WHERE barcode = $1);`
+
+	var found bool
+	err := repo.queryRow(ctx, sql, barcode).Scan(&found)
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo copyRepoImpl) GetCopyByBarcode(ctx context.Context, barcode string) (*entity.BookCopy, error) {
+	sql := `SELECT 
+				id, book_id, barcode, condition, location, status 
+			FROM 
+				book_copies 
+			WHERE 
+				barcode = $1 
+			FOR UPDATE;`
+
+	var bookCopy entity.BookCopy
+	err := repo.queryRow(ctx, sql, barcode).Scan(&bookCopy.Id, &bookCopy.BookId, &bookCopy.Barcode, &bookCopy.Condition, &bookCopy.Location, &bookCopy.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bookCopy, nil
+}
+
+func (repo copyRepoImpl) UpdateCopy(ctx context.Context, bookCopy *entity.BookCopy) (*entity.BookCopy, error) {
+	sql := `UPDATE 
+				book_copies 
+			SET 
+				condition = $2, 
+				location = $3, 
+				updated_at = NOW() 
+			WHERE 
+				id = $1 
+			RETURNING 
+				book_id, barcode, status;`
+
+	err := repo.queryRow(ctx, sql, bookCopy.Id, bookCopy.Condition, bookCopy.Location).Scan(&bookCopy.BookId, &bookCopy.Barcode, &bookCopy.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+// TakeAvailableCopy marks an available copy of the book as borrowed and returns its id, or 0 when none is left.
+func (repo copyRepoImpl) TakeAvailableCopy(ctx context.Context, bookId int) (int, error) {
+	sql := `WITH taken AS (
+				UPDATE 
+					book_copies 
+				SET 
+					status = 'borrowed', updated_at = NOW() 
+				WHERE 
+					id = (
+						SELECT id FROM book_copies 
+						WHERE book_id = $1 AND status = 'available' 
+						ORDER BY id 
+						LIMIT 1 
+						FOR UPDATE SKIP LOCKED
+					) 
+				RETURNING 
+					id
+			)
+			SELECT COALESCE((SELECT id FROM taken), 0);`
+
+	var id int
+	err := repo.queryRow(ctx, sql, bookId).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo copyRepoImpl) SetCopyStatus(ctx context.Context, id int, status string) error {
+	sql := `UPDATE book_copies SET status = $2, updated_at = NOW() WHERE id = $1;`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, sql, id, status)
+	} else {
+		_, err = repo.db.ExecContext(ctx, sql, id, status)
+	}
+
+	return err
+}
@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+)
+
+type FineRepo interface {
+	AddFineEntry(ctx context.Context, entry *entity.FineEntry) (*entity.FineEntry, error)
+	GetBalance(ctx context.Context, userId int) (int64, error)
+	ListFineEntries(ctx context.Context, userId int) ([]entity.FineEntry, error)
+}
+
+type fineRepoImpl struct {
+	db *sql.DB
+}
+
+func NewFineRepo(db *sql.DB) fineRepoImpl {
+	return fineRepoImpl{
+		db: db,
+	}
+}
+
+func (repo fineRepoImpl) AddFineEntry(ctx context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+	sql := `INSERT INTO 
+				fines (user_id, borrowing_record_id, kind, amount, note, created_by) 
+			VALUES 
+				($1, $2, $3, $4, $5, $6) 
+			RETURNING 
+				id, created_at`
+
+	tx := extractTx(ctx)
+	var err error
+	args := []any{entry.UserId, entry.BorrowId, entry.Kind, entry.Amount, entry.Note, entry.CreatedBy}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, args...).Scan(&entry.Id, &entry.CreatedAt)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, args...).Scan(&entry.Id, &entry.CreatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetBalance returns the outstanding debt of the user: charges minus payments and waivers.
+func (repo fineRepoImpl) GetBalance(ctx context.Context, userId int) (int64, error) {
+	sql := `SELECT 
+				COALESCE(SUM(CASE WHEN kind = 'charge' THEN amount ELSE -amount END), 0) 
+			FROM 
+				fines 
+			WHERE 
+				user_id = $1;`
+
+	tx := extractTx(ctx)
+	var err error
+	var balance int64
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, userId).Scan(&balance)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, userId).Scan(&balance)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (repo fineRepoImpl) ListFineEntries(ctx context.Context, userId int) ([]entity.FineEntry, error) {
+	sql := `SELECT 
+				id, user_id, borrowing_record_id, kind, amount, note, created_by, created_at 
+			FROM 
+				fines 
+			WHERE 
+				user_id = $1 
+			ORDER BY 
+				created_at DESC, id DESC;`
+
+	rows, err := repo.db.QueryContext(ctx, sql, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []entity.FineEntry{}
+	for rows.Next() {
+		var entry entity.FineEntry
+		err := rows.Scan(
+			&entry.Id,
+			&entry.UserId,
+			&entry.BorrowId,
+			&entry.Kind,
+			&entry.Amount,
+			&entry.Note,
+			&entry.CreatedBy,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
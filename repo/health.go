@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+)
+
+type HealthRepo interface {
+	Ping(ctx context.Context) error
+}
+
+type healthRepoImpl struct {
+	db *sql.DB
+}
+
+func NewHealthRepo(db *sql.DB) healthRepoImpl {
+	return healthRepoImpl{
+		db: db,
+	}
+}
+
+func (r healthRepoImpl) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
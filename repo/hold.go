@@ -0,0 +1,301 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type HoldRepo interface {
+	AddHold(ctx context.Context, hold *entity.Hold) (*entity.Hold, error)
+	IsHoldExisted(ctx context.Context, id int) (bool, error)
+	GetHoldById(ctx context.Context, id int) (*entity.Hold, error)
+	IsActiveHoldExisted(ctx context.Context, bookId int, userId int) (bool, error)
+	HasWaitingHolds(ctx context.Context, bookId int) (bool, error)
+	ListHoldsByUser(ctx context.Context, userId int) ([]entity.Hold, error)
+	ListHoldsByBook(ctx context.Context, bookId int) ([]entity.Hold, error)
+	CancelHold(ctx context.Context, id int) error
+	FulfilHold(ctx context.Context, bookId int, userId int) (int, error)
+	ReadyNextHold(ctx context.Context, bookId int, copyId int, pickupDays int) (bool, error)
+	ExpireReadyHolds(ctx context.Context, bookId int) ([]int, error)
+}
+
+type holdRepoImpl struct {
+	db *sql.DB
+}
+
+func NewHoldRepo(db *sql.DB) holdRepoImpl {
+	return holdRepoImpl{
+		db: db,
+	}
+}
+
+func (repo holdRepoImpl) exists(ctx context.Context, sql string, args ...any) (bool, error) {
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, args...).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, args...).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo holdRepoImpl) returningId(ctx context.Context, sql string, args ...any) (int, error) {
+	tx := extractTx(ctx)
+	var err error
+	var id int
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, args...).Scan(&id)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, args...).Scan(&id)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (repo holdRepoImpl) listHolds(ctx context.Context, sql string, args ...any) ([]entity.Hold, error) {
+	rows, err := repo.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := []entity.Hold{}
+	for rows.Next() {
+		var hold entity.Hold
+		var expiresAt *time.Time
+		err := rows.Scan(&hold.Id, &hold.BookId, &hold.UserId, &hold.Status, &hold.CreatedAt, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if expiresAt != nil {
+			hold.ExpiresAt = *expiresAt
+		}
+		holds = append(holds, hold)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (repo holdRepoImpl) AddHold(ctx context.Context, hold *entity.Hold) (*entity.Hold, error) {
+	sql := `INSERT INTO 
+				holds (book_id, user_id, status) 
+			VALUES 
+				($1, $2, 'waiting') 
+			RETURNING 
+				id, status, created_at`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, hold.BookId, hold.UserId).Scan(&hold.Id, &hold.Status, &hold.CreatedAt)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, hold.BookId, hold.UserId).Scan(&hold.Id, &hold.Status, &hold.CreatedAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (repo holdRepoImpl) IsHoldExisted(ctx context.Context, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM holds WHERE id = $1);`
+
+	return repo.exists(ctx, sql, id)
+}
+
+func (repo holdRepoImpl) GetHoldById(ctx context.Context, id int) (*entity.Hold, error) {
+	sql := `SELECT book_id, user_id, COALESCE(copy_id, 0), status, created_at, expires_at FROM holds WHERE id = $1 FOR UPDATE;`
+
+	tx := extractTx(ctx)
+	var err error
+	var expiresAt *time.Time
+	hold := entity.Hold{Id: id}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, id).Scan(&hold.BookId, &hold.UserId, &hold.CopyId, &hold.Status, &hold.CreatedAt, &expiresAt)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, id).Scan(&hold.BookId, &hold.UserId, &hold.CopyId, &hold.Status, &hold.CreatedAt, &expiresAt)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt != nil {
+		hold.ExpiresAt = *expiresAt
+	}
+
+	return &hold, nil
+}
+
+func (repo holdRepoImpl) IsActiveHoldExisted(ctx context.Context, bookId int, userId int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM holds WHERE book_id = $1 AND user_id = $2 AND status IN ('waiting', 'ready'));`
+
+	return repo.exists(ctx, sql, bookId, userId)
+}
+
+func (repo holdRepoImpl) HasWaitingHolds(ctx context.Context, bookId int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM holds WHERE book_id = $1 AND status = 'waiting');`
+
+	return repo.exists(ctx, sql, bookId)
+}
+
+func (repo holdRepoImpl) ListHoldsByUser(ctx context.Context, userId int) ([]entity.Hold, error) {
+	sql := `SELECT 
+				id, book_id, user_id, status, created_at, expires_at 
+			FROM 
+				holds 
+			WHERE 
+				user_id = $1 
+			ORDER BY 
+				created_at DESC, id DESC;`
+
+	return repo.listHolds(ctx, sql, userId)
+}
+
+func (repo holdRepoImpl) ListHoldsByBook(ctx context.Context, bookId int) ([]entity.Hold, error) {
+	sql := `SELECT 
+				id, book_id, user_id, status, created_at, expires_at 
+			FROM 
+				holds 
+			WHERE 
+				book_id = $1 AND status IN ('waiting', 'ready') 
+			ORDER BY 
+				created_at, id;`
+
+	return repo.listHolds(ctx, sql, bookId)
+}
+
+func (repo holdRepoImpl) CancelHold(ctx context.Context, id int) error {
+	sql := `UPDATE holds SET status = 'cancelled', updated_at = NOW() WHERE id = $1;`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, sql, id)
+	} else {
+		_, err = repo.db.ExecContext(ctx, sql, id)
+	}
+
+	return err
+}
+
+// FulfilHold closes the user's ready hold on the book and returns the copy it reserved, or 0 when there is none.
+func (repo holdRepoImpl) FulfilHold(ctx context.Context, bookId int, userId int) (int, error) {
+	sql := `WITH fulfilled AS (
+				UPDATE 
+					holds 
+				SET 
+					status = 'fulfilled', updated_at = NOW() 
+				WHERE 
+					book_id = $1 AND user_id = $2 AND status = 'ready' AND expires_at > LOCALTIMESTAMP 
+				RETURNING 
+					copy_id
+			)
+			SELECT COALESCE((SELECT copy_id FROM fulfilled), 0);`
+
+	return repo.returningId(ctx, sql, bookId, userId)
+}
+
+// ReadyNextHold reserves the copy for the oldest waiting hold of the book, if any.
+func (repo holdRepoImpl) ReadyNextHold(ctx context.Context, bookId int, copyId int, pickupDays int) (bool, error) {
+	var result sql.Result
+	var err error
+
+	sql := `UPDATE 
+				holds 
+			SET 
+				status = 'ready', 
+				copy_id = $2, 
+				expires_at = NOW() + make_interval(days => $3), 
+				updated_at = NOW() 
+			WHERE 
+				id = (
+					SELECT id FROM holds 
+					WHERE book_id = $1 AND status = 'waiting' 
+					ORDER BY created_at, id 
+					LIMIT 1 
+					FOR UPDATE
+				);`
+
+	tx := extractTx(ctx)
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, sql, bookId, copyId, pickupDays)
+	} else {
+		result, err = repo.db.ExecContext(ctx, sql, bookId, copyId, pickupDays)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// ExpireReadyHolds marks the ready holds not picked up in time and returns the copies they released.
+func (repo holdRepoImpl) ExpireReadyHolds(ctx context.Context, bookId int) ([]int, error) {
+	var rows *sql.Rows
+	var err error
+
+	sql := `UPDATE 
+				holds 
+			SET 
+				status = 'expired', updated_at = NOW() 
+			WHERE 
+				book_id = $1 AND status = 'ready' AND expires_at <= LOCALTIMESTAMP 
+			RETURNING 
+				copy_id;`
+
+	tx := extractTx(ctx)
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, sql, bookId)
+	} else {
+		rows, err = repo.db.QueryContext(ctx, sql, bookId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	copyIds := []int{}
+	for rows.Next() {
+		var copyId int
+		err := rows.Scan(&copyId)
+		if err != nil {
+			return nil, err
+		}
+		copyIds = append(copyIds, copyId)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return copyIds, nil
+}
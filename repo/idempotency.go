@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type IdempotencyRepo interface {
+	ReserveKey(ctx context.Context, key *entity.IdempotencyKey, ttl time.Duration, lease time.Duration) (int64, error)
+	GetKey(ctx context.Context, userId int, key string) (*entity.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error
+	ReleaseKey(ctx context.Context, key *entity.IdempotencyKey) error
+	PurgeExpiredKeys(ctx context.Context) (int64, error)
+}
+
+type idempotencyRepoImpl struct {
+	db *sql.DB
+}
+
+func NewIdempotencyRepo(db *sql.DB) idempotencyRepoImpl {
+	return idempotencyRepoImpl{
+		db: db,
+	}
+}
+
+// ReserveKey claims the key for a new request, taking over an expired one, or one whose request
+// has been in flight for longer than lease and most likely died with its server. It returns the number
+// of the new reservation, or 0 when the key is still held by an earlier request.
+func (repo idempotencyRepoImpl) ReserveKey(ctx context.Context, key *entity.IdempotencyKey, ttl time.Duration, lease time.Duration) (int64, error) {
+	sql := `WITH reserved AS (
+				INSERT INTO 
+					idempotency_keys (user_id, idempotency_key, fingerprint, expires_at) 
+				VALUES 
+					($1, $2, $3, NOW() + make_interval(secs => $4)) 
+				ON CONFLICT (user_id, idempotency_key) DO UPDATE 
+				SET 
+					fingerprint = EXCLUDED.fingerprint, 
+					status_code = NULL, 
+					response_body = NULL, 
+					expires_at = EXCLUDED.expires_at, 
+					reservation = nextval('idempotency_key_reservations'), 
+					created_at = NOW() 
+				WHERE 
+					idempotency_keys.expires_at < NOW() 
+					OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < NOW() - make_interval(secs => $5)) 
+				RETURNING 
+					reservation
+			)
+			SELECT COALESCE((SELECT reservation FROM reserved), 0);`
+
+	var reservation int64
+	err := repo.db.QueryRowContext(ctx, sql, key.UserId, key.Key, key.Fingerprint, ttl.Seconds(), lease.Seconds()).Scan(&reservation)
+	if err != nil {
+		return 0, err
+	}
+
+	return reservation, nil
+}
+
+func (repo idempotencyRepoImpl) GetKey(ctx context.Context, userId int, key string) (*entity.IdempotencyKey, error) {
+	sql := `SELECT 
+				fingerprint, COALESCE(status_code, 0), COALESCE(response_body, ''), expires_at 
+			FROM 
+				idempotency_keys 
+			WHERE 
+				user_id = $1 AND idempotency_key = $2;`
+
+	idempotencyKey := entity.IdempotencyKey{UserId: userId, Key: key}
+	err := repo.db.QueryRowContext(ctx, sql, userId, key).Scan(
+		&idempotencyKey.Fingerprint,
+		&idempotencyKey.StatusCode,
+		&idempotencyKey.ResponseBody,
+		&idempotencyKey.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+// SaveResponse stores the response of the request holding key.Reservation; it does nothing once
+// the key has been taken over by another request.
+func (repo idempotencyRepoImpl) SaveResponse(ctx context.Context, key *entity.IdempotencyKey) error {
+	sql := `UPDATE 
+				idempotency_keys 
+			SET 
+				status_code = $4, 
+				response_body = $5 
+			WHERE 
+				user_id = $1 AND idempotency_key = $2 AND reservation = $3;`
+
+	_, err := repo.db.ExecContext(ctx, sql, key.UserId, key.Key, key.Reservation, key.StatusCode, key.ResponseBody)
+
+	return err
+}
+
+// ReleaseKey deletes the key if it is still held by key.Reservation.
+func (repo idempotencyRepoImpl) ReleaseKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	sql := `DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND reservation = $3;`
+
+	_, err := repo.db.ExecContext(ctx, sql, key.UserId, key.Key, key.Reservation)
+
+	return err
+}
+
+// PurgeExpiredKeys deletes the keys past their expiry and returns how many were deleted.
+func (repo idempotencyRepoImpl) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	sql := `DELETE FROM idempotency_keys WHERE expires_at < NOW();`
+
+	result, err := repo.db.ExecContext(ctx, sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
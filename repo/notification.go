@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type NotificationRepo interface {
+	ListPendingReminders(ctx context.Context, dueSoonDays int, maxAttempts int, limit int) ([]entity.Reminder, error)
+	ClaimNotification(ctx context.Context, reminder *entity.Reminder, maxAttempts int) (bool, error)
+	MarkNotificationSent(ctx context.Context, id int) error
+	MarkNotificationFailed(ctx context.Context, id int, retryDelay time.Duration, reason string) error
+}
+
+type notificationRepoImpl struct {
+	db *sql.DB
+}
+
+func NewNotificationRepo(db *sql.DB) notificationRepoImpl {
+	return notificationRepoImpl{
+		db: db,
+	}
+}
+
+// ListPendingReminders returns the loans still out that are due within dueSoonDays or overdue,
+// and whose borrower has not been notified of it for the current due date. A reminder that failed
+// comes back once its retry is due, until it has failed maxAttempts times.
+func (repo notificationRepoImpl) ListPendingReminders(ctx context.Context, dueSoonDays int, maxAttempts int, limit int) ([]entity.Reminder, error) {
+	sql := `SELECT 
+				br.id, br.user_id, u.username, u.email, b.title, br.due_date, r.kind 
+			FROM 
+				borrowing_records br 
+				JOIN users u ON u.id = br.user_id 
+				JOIN books b ON b.id = br.book_id 
+				CROSS JOIN LATERAL (
+					SELECT CASE WHEN br.due_date < LOCALTIMESTAMP THEN 'overdue' ELSE 'due_soon' END AS kind
+				) r 
+			WHERE 
+				br.status = 'borrowed' 
+				AND br.deleted_at IS NULL 
+				AND br.due_date < LOCALTIMESTAMP + make_interval(days => $1) 
+				AND NOT EXISTS (
+					SELECT 1 FROM loan_notifications n 
+					WHERE n.borrowing_record_id = br.id AND n.kind = r.kind AND n.due_date = br.due_date 
+						AND NOT (n.status = 'failed' AND n.attempts < $2 AND n.next_attempt_at <= LOCALTIMESTAMP)
+				) 
+			ORDER BY 
+				br.due_date, br.id 
+			LIMIT $3;`
+
+	rows, err := repo.db.QueryContext(ctx, sql, dueSoonDays, maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []entity.Reminder{}
+	for rows.Next() {
+		var reminder entity.Reminder
+		err := rows.Scan(
+			&reminder.BorrowId,
+			&reminder.UserId,
+			&reminder.Username,
+			&reminder.Email,
+			&reminder.BookTitle,
+			&reminder.DueDate,
+			&reminder.Kind,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// ClaimNotification records the reminder as being sent, or takes back one that failed and is due for
+// a retry, setting its NotificationId and Attempts; it reports false when another run has it.
+func (repo notificationRepoImpl) ClaimNotification(ctx context.Context, reminder *entity.Reminder, maxAttempts int) (bool, error) {
+	sql := `WITH claimed AS (
+				INSERT INTO 
+					loan_notifications (borrowing_record_id, user_id, kind, due_date, status, attempts, attempted_at) 
+				VALUES 
+					($1, $2, $3, $4, 'sending', 1, LOCALTIMESTAMP) 
+				ON CONFLICT (borrowing_record_id, kind, due_date) DO UPDATE 
+				SET 
+					status = 'sending', 
+					attempts = loan_notifications.attempts + 1, 
+					attempted_at = LOCALTIMESTAMP, 
+					next_attempt_at = NULL 
+				WHERE 
+					loan_notifications.status = 'failed' 
+					AND loan_notifications.attempts < $5 
+					AND loan_notifications.next_attempt_at <= LOCALTIMESTAMP 
+				RETURNING 
+					id, attempts
+			)
+			SELECT COALESCE((SELECT id FROM claimed), 0), COALESCE((SELECT attempts FROM claimed), 0);`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, reminder.BorrowId, reminder.UserId, reminder.Kind, reminder.DueDate, maxAttempts).Scan(&reminder.NotificationId, &reminder.Attempts)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, reminder.BorrowId, reminder.UserId, reminder.Kind, reminder.DueDate, maxAttempts).Scan(&reminder.NotificationId, &reminder.Attempts)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return reminder.NotificationId != 0, nil
+}
+
+func (repo notificationRepoImpl) MarkNotificationSent(ctx context.Context, id int) error {
+	sql := `UPDATE loan_notifications SET status = 'sent', sent_at = LOCALTIMESTAMP, last_error = NULL WHERE id = $1;`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, sql, id)
+	} else {
+		_, err = repo.db.ExecContext(ctx, sql, id)
+	}
+
+	return err
+}
+
+// MarkNotificationFailed records a failed delivery, to be retried after retryDelay.
+func (repo notificationRepoImpl) MarkNotificationFailed(ctx context.Context, id int, retryDelay time.Duration, reason string) error {
+	sql := `UPDATE 
+				loan_notifications 
+			SET 
+				status = 'failed', 
+				next_attempt_at = LOCALTIMESTAMP + make_interval(secs => $2), 
+				last_error = $3 
+			WHERE 
+				id = $1;`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, sql, id, retryDelay.Seconds(), reason)
+	} else {
+		_, err = repo.db.ExecContext(ctx, sql, id, retryDelay.Seconds(), reason)
+	}
+
+	return err
+}
@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"archive_lib/entity"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type TokenRepo interface {
+	AddRefreshToken(ctx context.Context, token *entity.RefreshToken) error
+	IsRefreshTokenExisted(ctx context.Context, tokenHash string) (bool, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, id int) error
+	RevokeTokenFamily(ctx context.Context, familyId string) error
+	RevokeUserRefreshTokens(ctx context.Context, userId int) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	PurgeExpiredRevokedTokens(ctx context.Context) (int64, error)
+}
+
+type tokenRepoImpl struct {
+	db *sql.DB
+}
+
+func NewTokenRepo(db *sql.DB) tokenRepoImpl {
+	return tokenRepoImpl{
+		db: db,
+	}
+}
+
+func (repo tokenRepoImpl) exec(ctx context.Context, sql string, args ...any) error {
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		_, err = tx.ExecContext(ctx, sql, args...)
+	} else {
+		_, err = repo.db.ExecContext(ctx, sql, args...)
+	}
+
+	return err
+}
+
+func (repo tokenRepoImpl) AddRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	sql := `INSERT INTO 
+				refresh_tokens (user_id, family_id, token_hash, expires_at) 
+			VALUES 
+				($1, $2, $3, $4) 
+			RETURNING 
+				id`
+
+	tx := extractTx(ctx)
+	var err error
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, token.UserId, token.FamilyId, token.TokenHash, token.ExpiresAt).Scan(&token.Id)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, token.UserId, token.FamilyId, token.TokenHash, token.ExpiresAt).Scan(&token.Id)
+	}
+
+	return err
+}
+
+func (repo tokenRepoImpl) IsRefreshTokenExisted(ctx context.Context, tokenHash string) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM refresh_tokens WHERE token_hash = $1);`
+
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, tokenHash).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, tokenHash).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo tokenRepoImpl) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	sql := `SELECT 
+				id, user_id, family_id, expires_at, rotated_at IS NOT NULL, revoked_at IS NOT NULL 
+			FROM 
+				refresh_tokens 
+			WHERE 
+				token_hash = $1 
+			FOR UPDATE;`
+
+	tx := extractTx(ctx)
+	var err error
+	token := entity.RefreshToken{TokenHash: tokenHash}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, tokenHash).Scan(&token.Id, &token.UserId, &token.FamilyId, &token.ExpiresAt, &token.Rotated, &token.Revoked)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, tokenHash).Scan(&token.Id, &token.UserId, &token.FamilyId, &token.ExpiresAt, &token.Rotated, &token.Revoked)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (repo tokenRepoImpl) RotateRefreshToken(ctx context.Context, id int) error {
+	sql := `UPDATE refresh_tokens SET rotated_at = NOW(), updated_at = NOW() WHERE id = $1;`
+
+	return repo.exec(ctx, sql, id)
+}
+
+func (repo tokenRepoImpl) RevokeTokenFamily(ctx context.Context, familyId string) error {
+	sql := `UPDATE 
+				refresh_tokens 
+			SET 
+				revoked_at = NOW(), updated_at = NOW() 
+			WHERE 
+				family_id = $1 AND revoked_at IS NULL;`
+
+	return repo.exec(ctx, sql, familyId)
+}
+
+func (repo tokenRepoImpl) RevokeUserRefreshTokens(ctx context.Context, userId int) error {
+	sql := `UPDATE 
+				refresh_tokens 
+			SET 
+				revoked_at = NOW(), updated_at = NOW() 
+			WHERE 
+				user_id = $1 AND revoked_at IS NULL;`
+
+	return repo.exec(ctx, sql, userId)
+}
+
+func (repo tokenRepoImpl) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	sql := `INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING;`
+
+	return repo.exec(ctx, sql, jti, expiresAt)
+}
+
+func (repo tokenRepoImpl) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1);`
+
+	var revoked bool
+	err := repo.db.QueryRowContext(ctx, sql, jti).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// PurgeExpiredRevokedTokens deletes the revocations of access tokens that have expired anyway
+// and returns how many were deleted.
+func (repo tokenRepoImpl) PurgeExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	sql := `DELETE FROM revoked_tokens WHERE expires_at < NOW();`
+
+	result, err := repo.db.ExecContext(ctx, sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
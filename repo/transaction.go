@@ -1,12 +1,14 @@
 package repo
 
 import (
+	"archive_lib/util/metrics"
 	"context"
 	"database/sql"
 )
 
 type TransactionRepo interface {
 	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type transactionRepoImpl struct {
@@ -41,6 +43,7 @@ func (t transactionRepoImpl) WithinTransaction(ctx context.Context, fn func(ctx
 
 	err = fn(injectTx(ctx, tx))
 	if err != nil {
+		metrics.TransactionRollbacks.Inc()
 		if errRollback := tx.Rollback(); errRollback != nil {
 			return err
 		}
@@ -48,8 +51,34 @@ func (t transactionRepoImpl) WithinTransaction(ctx context.Context, fn func(ctx
 	}
 
 	if err := tx.Commit(); err != nil {
+		metrics.TransactionRollbacks.Inc()
 		return err
 	}
 
 	return nil
 }
+
+// WithinSavepoint runs fn in a savepoint of the transaction carried by ctx, so a failing fn only
+// undoes its own statements and the transaction stays usable. Without a transaction it starts one.
+func (t transactionRepoImpl) WithinSavepoint(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := extractTx(ctx)
+	if tx == nil {
+		return t.WithinTransaction(ctx, fn)
+	}
+
+	_, err := tx.ExecContext(ctx, `SAVEPOINT sp;`)
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	if err != nil {
+		if _, errRollback := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT sp;`); errRollback != nil {
+			return errRollback
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `RELEASE SAVEPOINT sp;`)
+	return err
+}
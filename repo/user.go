@@ -1,14 +1,25 @@
 package repo
 
 import (
+	"archive_lib/apperror"
 	"archive_lib/entity"
 	"context"
 	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// uniqueViolation is the Postgres error code of an insert that breaks a unique constraint.
+const uniqueViolation = "23505"
+
 type UserRepo interface {
 	IsEmailExisted(ctx context.Context, email string) (bool, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	IsUserExisted(ctx context.Context, id int) (bool, error)
+	GetUserById(ctx context.Context, id int) (*entity.User, error)
+	LockUser(ctx context.Context, id int) (bool, error)
+	AddUser(ctx context.Context, user *entity.User) (*entity.User, error)
 }
 
 type userRepoImpl struct {
@@ -34,10 +45,10 @@ func (repo userRepoImpl) IsEmailExisted(ctx context.Context, email string) (bool
 }
 
 func (repo userRepoImpl) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	sql := `SELECT id, pass FROM users WHERE email = $1;`
+	sql := `SELECT id, username, pass, role FROM users WHERE email = $1;`
 
 	var user entity.User
-	err := repo.db.QueryRowContext(ctx, sql, email).Scan(&user.Id, &user.Password)
+	err := repo.db.QueryRowContext(ctx, sql, email).Scan(&user.Id, &user.Username, &user.Password, &user.Role)
 	if err != nil {
 		return nil, err
 	}
@@ -45,3 +56,85 @@ func (repo userRepoImpl) GetUserByEmail(ctx context.Context, email string) (*ent
 
 	return &user, nil
 }
+
+func (repo userRepoImpl) IsUserExisted(ctx context.Context, id int) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1 AND deleted_at IS NULL);`
+
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, id).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, id).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo userRepoImpl) GetUserById(ctx context.Context, id int) (*entity.User, error) {
+	sql := `SELECT username, email, role FROM users WHERE id = $1 AND deleted_at IS NULL;`
+
+	tx := extractTx(ctx)
+	var err error
+	user := entity.User{Id: id}
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, id).Scan(&user.Username, &user.Email, &user.Role)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, id).Scan(&user.Username, &user.Email, &user.Role)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// LockUser locks the row of the user until the transaction ends, reporting false when there is no such user.
+// Checks over the loans or fines of a user take it first, so that concurrent requests of the same user
+// are decided one after the other.
+func (repo userRepoImpl) LockUser(ctx context.Context, id int) (bool, error) {
+	sql := `WITH locked AS (
+				SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+			)
+			SELECT EXISTS(SELECT 1 FROM locked);`
+
+	tx := extractTx(ctx)
+	var err error
+	var found bool
+
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, sql, id).Scan(&found)
+	} else {
+		err = repo.db.QueryRowContext(ctx, sql, id).Scan(&found)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+func (repo userRepoImpl) AddUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	sql := `INSERT INTO users (username, email, pass, role) VALUES ($1, $2, $3, $4) RETURNING id;`
+
+	err := repo.db.QueryRowContext(ctx, sql, user.Username, user.Email, user.Password, user.Role).Scan(&user.Id)
+	// the email may be taken between the check in Register and this insert
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, apperror.ErrDuplicateEmail{}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,33 @@
+package setup
+
+import (
+	"archive_lib/dto"
+	"runtime"
+	"runtime/debug"
+)
+
+// Version and Commit can be set at build time, e.g. go build -ldflags "-X archive_lib/setup.Version=1.2.0".
+// Commit falls back to the VCS revision Go stamps into the binary.
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+func NewBuildInfo() dto.BuildInfo {
+	commit := Commit
+	if commit == "" {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					commit = setting.Value
+				}
+			}
+		}
+	}
+
+	return dto.BuildInfo{
+		Version:   Version,
+		Commit:    commit,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -0,0 +1,37 @@
+package setup
+
+import (
+	"archive_lib/util/logger"
+	"context"
+	"time"
+)
+
+// CleanupTask deletes the rows of a table that are no longer needed, returning how many were deleted.
+type CleanupTask struct {
+	Name  string
+	Purge func(ctx context.Context) (int64, error)
+}
+
+// RunCleanupScheduler runs every task right away and then every interval, until ctx is cancelled.
+func RunCleanupScheduler(ctx context.Context, interval time.Duration, tasks ...CleanupTask) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, task := range tasks {
+			purged, err := task.Purge(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Log.Errorf("purging %s: %v", task.Name, err)
+			}
+			if purged > 0 {
+				logger.Log.Infof("purged %d %s", purged, task.Name)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
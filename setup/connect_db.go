@@ -1,25 +1,27 @@
 package setup
 
 import (
+	"archive_lib/config"
+	"archive_lib/util/tracing"
 	"database/sql"
-	"fmt"
-	"os"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 )
 
-func ConnectDB() (*sql.DB, error) {
-	username := os.Getenv("DB_USER_NAME")
-	password := os.Getenv("DB_USER_PASSWORD")
-	host := os.Getenv("DB_HOST")
-	port := os.Getenv("DB_PORT")
-	databaseName := os.Getenv("DB_NAME")
-	databaseURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s", username, password, host, port, databaseName)
-
-	db, err := sql.Open("pgx", databaseURL)
+func ConnectDB(cfg config.DBConfig) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(cfg.URL())
 	if err != nil {
 		return nil, err
 	}
+	connConfig.Tracer = tracing.QueryTracer{}
+
+	db := stdlib.OpenDB(*connConfig)
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
 	err = db.Ping()
 	if err != nil {
@@ -0,0 +1,22 @@
+package setup
+
+import (
+	"archive_lib/config"
+	"archive_lib/entity"
+	"archive_lib/usecase"
+)
+
+// NewLoanConfig turns the loan settings of the configuration into the loan rules of the usecases.
+func NewLoanConfig(cfg config.LoanConfig) usecase.LoanConfig {
+	return usecase.LoanConfig{
+		LoanDays:       cfg.LoanDays,
+		MaxRenewals:    cfg.MaxRenewals,
+		HoldPickupDays: cfg.HoldPickupDays,
+		MaxActiveLoans: map[string]int{
+			entity.RoleMember:    cfg.MaxLoansMember,
+			entity.RoleLibrarian: cfg.MaxLoansLibrarian,
+		},
+		DailyFine:     cfg.DailyFine,
+		FineThreshold: cfg.FineThreshold,
+	}
+}
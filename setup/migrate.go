@@ -0,0 +1,84 @@
+package setup
+
+import (
+	"archive_lib/config"
+	"archive_lib/migration"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// Migrate runs the migrate subcommand: up applies the pending migrations, down reverts
+// the last one (or the given number of them), and status lists them all.
+func Migrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	// migrating only needs the database, so the rest of the configuration is not required
+	dbConfig, err := config.LoadDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := ConnectDB(*dbConfig)
+	if err != nil {
+		log.Fatalf("unable to connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("unable to load the migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		err = migrateUp(ctx, migrator)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatalf("invalid steps: %q", args[1])
+			}
+		}
+
+		var reverted []migration.Migration
+		reverted, err = migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			log.Printf("reverted %04d_%s", m.Version, m.Name)
+		}
+	case "status":
+		var statuses []migration.Status
+		statuses, err = migrator.Status(ctx)
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+
+	if err != nil {
+		log.Fatalf("migrate %s: %v", args[0], err)
+	}
+}
+
+func migrateUp(ctx context.Context, migrator *migration.Migrator) error {
+	applied, err := migrator.Up(ctx)
+	for _, m := range applied {
+		log.Printf("applied %04d_%s", m.Version, m.Name)
+	}
+
+	return err
+}
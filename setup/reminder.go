@@ -0,0 +1,70 @@
+package setup
+
+import (
+	"archive_lib/config"
+	"archive_lib/usecase"
+	"archive_lib/util/logger"
+	"archive_lib/util/notifier"
+	"context"
+	"log"
+	"os"
+	"time"
+)
+
+// NewReminderConfig turns the reminder settings of the configuration into the reminder rules of the usecase.
+func NewReminderConfig(cfg config.ReminderConfig) usecase.ReminderConfig {
+	return usecase.ReminderConfig{
+		DueSoonDays: cfg.DueSoonDays,
+		BatchSize:   cfg.BatchSize,
+		MaxAttempts: cfg.MaxAttempts,
+		RetryDelay:  cfg.RetryDelay,
+	}
+}
+
+// NewNotifier sends reminders by email when the notifier is smtp, and writes them to
+// the notification log file (or stdout) otherwise.
+func NewNotifier(cfg config.NotifierConfig) notifier.Notifier {
+	if cfg.Kind == "smtp" {
+		return notifier.NewSMTPNotifier(
+			cfg.SMTP.Host,
+			cfg.SMTP.Port,
+			cfg.SMTP.Username,
+			cfg.SMTP.Password,
+			cfg.SMTP.From,
+		)
+	}
+
+	if cfg.LogFile == "" {
+		return notifier.NewLogNotifier(os.Stdout)
+	}
+
+	file, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Fatalf("unable to open the notification log file: %v", err)
+	}
+
+	return notifier.NewLogNotifier(file)
+}
+
+// RunReminderScheduler sends the pending reminders right away and then every interval,
+// until ctx is cancelled.
+func RunReminderScheduler(ctx context.Context, reminderUsecase usecase.ReminderUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := reminderUsecase.SendReminders(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Log.Errorf("sending reminders: %v", err)
+		}
+		if sent > 0 {
+			logger.Log.Infof("sent %d reminder(s)", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
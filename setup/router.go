@@ -1,8 +1,10 @@
 package setup
 
 import (
+	"archive_lib/entity"
 	"archive_lib/handler"
 	"archive_lib/middleware"
+	"archive_lib/util/metrics"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,27 +13,80 @@ type Handlers struct {
 	userHandler   *handler.UserHandler
 	bookHandler   *handler.BookHandler
 	borrowHandler *handler.BorrowHandler
+	authorHandler *handler.AuthorHandler
+	holdHandler   *handler.HoldHandler
+	fineHandler   *handler.FineHandler
+	copyHandler   *handler.CopyHandler
+	healthHandler *handler.HealthHandler
 }
 
-func NewHandlers(userHandler *handler.UserHandler, bookHandler *handler.BookHandler, borrowHandler *handler.BorrowHandler) *Handlers {
+func NewHandlers(userHandler *handler.UserHandler, bookHandler *handler.BookHandler, borrowHandler *handler.BorrowHandler, authorHandler *handler.AuthorHandler, holdHandler *handler.HoldHandler, fineHandler *handler.FineHandler, copyHandler *handler.CopyHandler, healthHandler *handler.HealthHandler) *Handlers {
 	return &Handlers{
 		userHandler,
 		bookHandler,
 		borrowHandler,
+		authorHandler,
+		holdHandler,
+		fineHandler,
+		copyHandler,
+		healthHandler,
 	}
 }
 
 func NewRouter(h *Handlers) *gin.Engine {
 	router := gin.New()
+	router.ContextWithFallback = true
 	router.Use(gin.Recovery())
+	router.Use(middleware.TracingMiddleware)
+	router.Use(middleware.MetricsMiddleware)
 	router.Use(middleware.LoggerMiddleware)
 	router.Use(middleware.ErrorMiddleware)
 
+	librarianOnly := middleware.RequireRole(entity.RoleLibrarian)
+	memberOnly := middleware.RequireRole(entity.RoleMember)
+
+	router.GET("/healthz", h.healthHandler.LivenessHandler)
+	router.GET("/readyz", h.healthHandler.ReadinessHandler)
+	router.GET("/status", middleware.AuthMiddleware, librarianOnly, h.healthHandler.StatusHandler)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 	router.POST("/login", h.userHandler.Login)
+	router.POST("/register", h.userHandler.Register)
+	router.POST("/token/refresh", h.userHandler.Refresh)
+	router.POST("/logout", middleware.AuthMiddleware, h.userHandler.Logout)
 	router.GET("/books", h.bookHandler.GetBooksHandler)
-	router.POST("/books", h.bookHandler.AddBookHandler)
-	router.POST("/borrowing-records", middleware.AuthMiddleware, h.borrowHandler.BorrowBookHandler)
-	router.PATCH("/borrowing-records", middleware.AuthMiddleware, h.borrowHandler.ReturnBookHandler)
+	router.GET("/books/search", h.bookHandler.SearchBooksHandler)
+	router.POST("/books", middleware.AuthMiddleware, librarianOnly, middleware.IdempotencyMiddleware, h.bookHandler.AddBookHandler)
+	router.PUT("/books/:id", middleware.AuthMiddleware, librarianOnly, h.bookHandler.UpdateBookHandler)
+	router.PATCH("/books/:id", middleware.AuthMiddleware, librarianOnly, h.bookHandler.PatchBookHandler)
+	router.DELETE("/books/:id", middleware.AuthMiddleware, librarianOnly, h.bookHandler.DeleteBookHandler)
+	router.POST("/books/:id/restore", middleware.AuthMiddleware, librarianOnly, h.bookHandler.RestoreBookHandler)
+	router.POST("/books/:id/holds", middleware.AuthMiddleware, memberOnly, h.holdHandler.PlaceHoldHandler)
+	router.GET("/books/:id/holds", middleware.AuthMiddleware, librarianOnly, h.holdHandler.GetBookHoldsHandler)
+	router.GET("/books/:id/copies", middleware.AuthMiddleware, librarianOnly, h.copyHandler.GetBookCopiesHandler)
+	router.POST("/books/:id/copies", middleware.AuthMiddleware, librarianOnly, h.copyHandler.AddCopyHandler)
+	router.PATCH("/copies/:id", middleware.AuthMiddleware, librarianOnly, h.copyHandler.PatchCopyHandler)
+	router.GET("/me/holds", middleware.AuthMiddleware, memberOnly, h.holdHandler.GetMyHoldsHandler)
+	router.DELETE("/holds/:id", middleware.AuthMiddleware, memberOnly, h.holdHandler.CancelHoldHandler)
+	router.GET("/me/borrowing-records", middleware.AuthMiddleware, h.borrowHandler.GetMyBorrowsHandler)
+	router.GET("/me/fines", middleware.AuthMiddleware, h.fineHandler.GetMyFinesHandler)
+	router.GET("/users/:id/fines", middleware.AuthMiddleware, librarianOnly, h.fineHandler.GetUserFinesHandler)
+	router.POST("/users/:id/fines/payments", middleware.AuthMiddleware, librarianOnly, h.fineHandler.RecordPaymentHandler)
+	router.POST("/users/:id/fines/waivers", middleware.AuthMiddleware, librarianOnly, h.fineHandler.WaiveFineHandler)
+	router.GET("/authors", h.authorHandler.GetAuthorsHandler)
+	router.POST("/authors", middleware.AuthMiddleware, librarianOnly, h.authorHandler.AddAuthorHandler)
+	router.GET("/authors/:id", h.authorHandler.GetAuthorHandler)
+	router.PUT("/authors/:id", middleware.AuthMiddleware, librarianOnly, h.authorHandler.UpdateAuthorHandler)
+	router.DELETE("/authors/:id", middleware.AuthMiddleware, librarianOnly, h.authorHandler.DeleteAuthorHandler)
+	router.GET("/authors/:id/books", h.authorHandler.GetAuthorBooksHandler)
+	router.GET("/borrowing-records", middleware.AuthMiddleware, librarianOnly, h.borrowHandler.GetBorrowsHandler)
+	router.GET("/borrowing-records/:id", middleware.AuthMiddleware, h.borrowHandler.GetBorrowHandler)
+	router.POST("/borrowing-records", middleware.AuthMiddleware, memberOnly, middleware.IdempotencyMiddleware, h.borrowHandler.BorrowBookHandler)
+	router.PATCH("/borrowing-records", middleware.AuthMiddleware, memberOnly, middleware.IdempotencyMiddleware, h.borrowHandler.ReturnBookHandler)
+	router.POST("/borrowing-records/checkout", middleware.AuthMiddleware, librarianOnly, middleware.IdempotencyMiddleware, h.borrowHandler.CheckoutHandler)
+	router.POST("/borrowing-records/batch", middleware.AuthMiddleware, memberOnly, middleware.IdempotencyMiddleware, h.borrowHandler.BatchBorrowHandler)
+	router.PATCH("/borrowing-records/batch", middleware.AuthMiddleware, memberOnly, middleware.IdempotencyMiddleware, h.borrowHandler.BatchReturnHandler)
+	router.POST("/borrowing-records/:id/renew", middleware.AuthMiddleware, memberOnly, h.borrowHandler.RenewBorrowHandler)
+	router.PATCH("/borrowing-records/:id/status", middleware.AuthMiddleware, librarianOnly, h.borrowHandler.UpdateBorrowStatusHandler)
 
 	return router
 }
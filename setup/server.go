@@ -1,11 +1,16 @@
 package setup
 
 import (
+	"archive_lib/config"
 	"archive_lib/handler"
+	"archive_lib/middleware"
+	"archive_lib/migration"
 	"archive_lib/repo"
 	"archive_lib/usecase"
 	"archive_lib/util"
 	"archive_lib/util/logger"
+	"archive_lib/util/metrics"
+	"archive_lib/util/tracing"
 	"context"
 	"log"
 	"net/http"
@@ -13,51 +18,113 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
-
-	"github.com/joho/godotenv"
 )
 
-const Timeout = 5
-
 func Run() {
-	err := godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:       cfg.Tracing.Exporter,
+		OTLPEndpoint:   cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure:   cfg.Tracing.OTLPInsecure,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		ServiceName:    cfg.Tracing.ServiceName,
+		ServiceVersion: Version,
+	})
 	if err != nil {
-		log.Fatal("error loading .env file")
+		log.Fatalf("unable to set up tracing: %v", err)
 	}
 
-	db, err := ConnectDB()
+	loanConfig := NewLoanConfig(cfg.Loan)
+
+	db, err := ConnectDB(cfg.DB)
 	if err != nil {
 		log.Fatalf("unable to connect to the database: %v", err)
 	}
 	defer db.Close()
+	metrics.RegisterDB(db)
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("unable to load the migrations: %v", err)
+	}
+
+	if cfg.Server.AutoMigrate {
+		err = migrateUp(context.Background(), migrator)
+		if err != nil {
+			log.Fatalf("unable to migrate the database: %v", err)
+		}
+	}
 
 	bcrypt := util.NewBcrypt()
-	jwt := util.NewJWT()
+	jwtConfig := util.JWTConfig{
+		Secret:         cfg.JWT.Secret,
+		Issuer:         cfg.JWT.Issuer,
+		AccessTokenTTL: cfg.JWT.AccessTokenTTL,
+	}
+	jwt := util.NewJWT(jwtConfig)
+	middleware.SetJWTConfig(jwtConfig)
 	logger.SetLogger(logger.NewLogrusLogger())
 	util.FormatValidatedField()
 
 	txRepo := repo.NewTransactionRepo(db)
 
+	tokenRepo := repo.NewTokenRepo(db)
+	middleware.SetTokenRevocationChecker(tokenRepo)
+
+	idempotencyRepo := repo.NewIdempotencyRepo(db)
+	middleware.SetIdempotencyStore(idempotencyRepo, cfg.Idempotency.KeyTTL, cfg.Idempotency.Lease)
+
 	userRepo := repo.NewUserRepo(db)
-	userUsecase := usecase.NewUserUsecase(userRepo, bcrypt, jwt)
+	userUsecase := usecase.NewUserUsecase(userRepo, tokenRepo, txRepo, bcrypt, jwt, usecase.TokenConfig{
+		AccessTokenTTL:  cfg.JWT.AccessTokenTTL,
+		RefreshTokenTTL: cfg.JWT.RefreshTokenTTL,
+	})
 	userHandler := handler.NewUserHandler(userUsecase)
 
 	bookRepo := repo.NewBookRepo(db)
 	bookUsecase := usecase.NewBookUsecase(bookRepo)
 	bookHandler := handler.NewBookHandler(bookUsecase)
 
+	copyRepo := repo.NewCopyRepo(db)
+	holdRepo := repo.NewHoldRepo(db)
+	holdUsecase := usecase.NewHoldUsecase(holdRepo, bookRepo, copyRepo, txRepo, loanConfig)
+	holdHandler := handler.NewHoldHandler(holdUsecase)
+
 	borrowRepo := repo.NewBorrowRepo(db)
-	borrowUsecase := usecase.NewBorrowUsecase(borrowRepo, bookRepo, txRepo)
+	copyUsecase := usecase.NewCopyUsecase(copyRepo, bookRepo, borrowRepo, holdRepo, txRepo, loanConfig)
+	copyHandler := handler.NewCopyHandler(copyUsecase)
+
+	fineRepo := repo.NewFineRepo(db)
+	fineUsecase := usecase.NewFineUsecase(fineRepo, userRepo, txRepo)
+	fineHandler := handler.NewFineHandler(fineUsecase)
+
+	borrowPolicy := usecase.NewDefaultBorrowPolicies(borrowRepo, userRepo, fineRepo, loanConfig)
+	borrowUsecase := usecase.NewBorrowUsecase(borrowRepo, bookRepo, userRepo, copyRepo, holdRepo, fineRepo, txRepo, borrowPolicy, loanConfig)
 	borrowHandler := handler.NewBorrowHandler(borrowUsecase)
 
-	handlers := NewHandlers(&userHandler, &bookHandler, &borrowHandler)
+	authorRepo := repo.NewAuthorRepo(db)
+	authorUsecase := usecase.NewAuthorUsecase(authorRepo, bookRepo)
+	authorHandler := handler.NewAuthorHandler(authorUsecase)
+
+	notificationRepo := repo.NewNotificationRepo(db)
+	reminderUsecase := usecase.NewReminderUsecase(notificationRepo, NewNotifier(cfg.Notifier), NewReminderConfig(cfg.Reminder))
+
+	healthRepo := repo.NewHealthRepo(db)
+	healthUsecase := usecase.NewHealthUsecase(healthRepo, migrator, NewBuildInfo())
+	healthHandler := handler.NewHealthHandler(healthUsecase)
+
+	handlers := NewHandlers(&userHandler, &bookHandler, &borrowHandler, &authorHandler, &holdHandler, &fineHandler, &copyHandler, &healthHandler)
 	router := NewRouter(handlers)
 
 	s := &http.Server{
-		Addr:         ":" + os.Getenv("SERVER_PORT"),
+		Addr:         ":" + cfg.Server.Port,
 		Handler:      router,
-		ReadTimeout:  Timeout * time.Second,
-		WriteTimeout: Timeout * time.Second,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
 	}
 
 	go func() {
@@ -66,19 +133,47 @@ func Run() {
 		}
 	}()
 
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	schedulerDone := make(chan struct{})
+	go func() {
+		RunReminderScheduler(schedulerCtx, reminderUsecase, cfg.Reminder.Interval)
+		close(schedulerDone)
+	}()
+
+	cleanupDone := make(chan struct{})
+	go func() {
+		RunCleanupScheduler(schedulerCtx, cfg.Cleanup.Interval,
+			CleanupTask{Name: "expired idempotency keys", Purge: idempotencyRepo.PurgeExpiredKeys},
+			CleanupTask{Name: "expired revoked tokens", Purge: tokenRepo.PurgeExpiredRevokedTokens},
+		)
+		close(cleanupDone)
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutdown server...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), Timeout*time.Second)
+	// fail readiness first so load balancers stop routing here before the server stops accepting
+	healthUsecase.StartDraining()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	stopScheduler()
+	<-schedulerDone
+	<-cleanupDone
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	if err := s.Shutdown(ctx); err != nil {
 		log.Fatalf("Server shutdown error: %v", err)
 	}
 
+	if err := shutdownTracing(ctx); err != nil {
+		log.Printf("Tracing shutdown error: %v", err)
+	}
+
 	<-ctx.Done()
 
 	log.Println("Server exited gracefully")
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/repo"
+	"archive_lib/util/tracing"
+	"context"
+)
+
+type AuthorUsecase interface {
+	ListAuthors(ctx context.Context) ([]*dto.AuthorResponse, error)
+	GetAuthor(ctx context.Context, id int) (*dto.AuthorResponse, error)
+	AddAuthor(ctx context.Context, authorRequest *dto.AuthorRequest) (*dto.AuthorResponse, error)
+	UpdateAuthor(ctx context.Context, id int, authorRequest *dto.AuthorRequest) (*dto.AuthorResponse, error)
+	DeleteAuthor(ctx context.Context, id int) (*dto.AuthorResponse, error)
+	GetAuthorBooks(ctx context.Context, id int) (*dto.AuthorBooksResponse, error)
+}
+
+type authorUsecaseImpl struct {
+	authorRepo repo.AuthorRepo
+	bookRepo   repo.BookRepo
+}
+
+func NewAuthorUsecase(ar repo.AuthorRepo, br repo.BookRepo) authorUsecaseImpl {
+	return authorUsecaseImpl{
+		authorRepo: ar,
+		bookRepo:   br,
+	}
+}
+
+func (uc authorUsecaseImpl) convertAuthorToRes(author *entity.Author) *dto.AuthorResponse {
+	return &dto.AuthorResponse{
+		Id:   author.Id,
+		Name: author.Name,
+	}
+}
+
+func (uc authorUsecaseImpl) convertBookToRes(book *entity.Book) *dto.BookResponse {
+	var cover string
+	if book.Cover != nil {
+		cover = *book.Cover
+	}
+
+	return &dto.BookResponse{
+		Id:          book.Id,
+		Title:       book.Title,
+		Description: book.Description,
+		Quantity:    book.Quantity,
+		Cover:       cover,
+	}
+}
+
+func (uc authorUsecaseImpl) ListAuthors(ctx context.Context) ([]*dto.AuthorResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.ListAuthors")
+	defer span.End()
+
+	authors, err := uc.authorRepo.ListAuthors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	authorsResponse := []*dto.AuthorResponse{}
+	for _, author := range authors {
+		authorsResponse = append(authorsResponse, uc.convertAuthorToRes(&author))
+	}
+	return authorsResponse, nil
+}
+
+func (uc authorUsecaseImpl) GetAuthor(ctx context.Context, id int) (*dto.AuthorResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.GetAuthor")
+	defer span.End()
+
+	found, err := uc.authorRepo.IsAuthorExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrAuthorNotFound{}
+	}
+
+	author, err := uc.authorRepo.GetAuthorById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertAuthorToRes(author), nil
+}
+
+func (uc authorUsecaseImpl) AddAuthor(ctx context.Context, authorRequest *dto.AuthorRequest) (*dto.AuthorResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.AddAuthor")
+	defer span.End()
+
+	addedAuthor, err := uc.authorRepo.AddAuthor(ctx, &entity.Author{Name: authorRequest.Name})
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertAuthorToRes(addedAuthor), nil
+}
+
+func (uc authorUsecaseImpl) UpdateAuthor(ctx context.Context, id int, authorRequest *dto.AuthorRequest) (*dto.AuthorResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.UpdateAuthor")
+	defer span.End()
+
+	found, err := uc.authorRepo.IsAuthorExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrAuthorNotFound{}
+	}
+
+	updatedAuthor, err := uc.authorRepo.UpdateAuthor(ctx, &entity.Author{Id: id, Name: authorRequest.Name})
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertAuthorToRes(updatedAuthor), nil
+}
+
+func (uc authorUsecaseImpl) DeleteAuthor(ctx context.Context, id int) (*dto.AuthorResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.DeleteAuthor")
+	defer span.End()
+
+	found, err := uc.authorRepo.IsAuthorExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrAuthorNotFound{}
+	}
+
+	hasBooks, err := uc.authorRepo.HasActiveBooks(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hasBooks {
+		return nil, apperror.ErrAuthorHasBooks{}
+	}
+
+	deletedAuthor, err := uc.authorRepo.DeleteAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertAuthorToRes(deletedAuthor), nil
+}
+
+func (uc authorUsecaseImpl) GetAuthorBooks(ctx context.Context, id int) (*dto.AuthorBooksResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthorUsecase.GetAuthorBooks")
+	defer span.End()
+
+	found, err := uc.authorRepo.IsAuthorExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrAuthorNotFound{}
+	}
+
+	author, err := uc.authorRepo.GetAuthorById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	books, err := uc.bookRepo.GetBooksByAuthorId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	booksResponse := []*dto.BookResponse{}
+	for _, book := range books {
+		booksResponse = append(booksResponse, uc.convertBookToRes(&book))
+	}
+
+	return &dto.AuthorBooksResponse{
+		Id:    author.Id,
+		Name:  author.Name,
+		Books: booksResponse,
+	}, nil
+}
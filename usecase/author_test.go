@@ -0,0 +1,217 @@
+package usecase_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	authors             = []entity.Author{author}
+	authorsResponse     = []*dto.AuthorResponse{authorResponse}
+	authorRequest       = &dto.AuthorRequest{Name: "John The Poet"}
+	authorBooks         = []entity.Book{book}
+	authorBooksResponse = &dto.AuthorBooksResponse{
+		Id:    authorId,
+		Name:  "John The Poet",
+		Books: []*dto.BookResponse{bookResponse},
+	}
+)
+
+func TestListAuthorsUsecase(t *testing.T) {
+	t.Run("should return list of authors when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("ListAuthors", ctx).Return(authors, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		actualAuthorsResponse, _ := authorUsecase.ListAuthors(ctx)
+
+		assert.Equal(t, authorsResponse, actualAuthorsResponse)
+	})
+
+	t.Run("should return error when list authors encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("ListAuthors", ctx).Return(nil, errors.New("server error"))
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.ListAuthors(ctx)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetAuthorUsecase(t *testing.T) {
+	t.Run("should return the author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockAuthorRepo.On("GetAuthorById", ctx, authorId).Return(&author, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		actualAuthorResponse, _ := authorUsecase.GetAuthor(ctx, authorId)
+
+		assert.Equal(t, authorResponse, actualAuthorResponse)
+	})
+
+	t.Run("should return ErrAuthorNotFound when getting non-existing author", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(false, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.GetAuthor(ctx, authorId)
+
+		assert.Equal(t, apperror.ErrAuthorNotFound{}, err)
+	})
+}
+
+func TestAddAuthorUsecase(t *testing.T) {
+	t.Run("should return the added author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("AddAuthor", ctx, &entity.Author{Name: "John The Poet"}).Return(&author, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		actualAuthorResponse, _ := authorUsecase.AddAuthor(ctx, authorRequest)
+
+		assert.Equal(t, authorResponse, actualAuthorResponse)
+	})
+
+	t.Run("should return error when add author encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("AddAuthor", ctx, &entity.Author{Name: "John The Poet"}).Return(nil, errors.New("server error"))
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.AddAuthor(ctx, authorRequest)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestUpdateAuthorUsecase(t *testing.T) {
+	t.Run("should return the updated author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockAuthorRepo.On("UpdateAuthor", ctx, &author).Return(&author, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		actualAuthorResponse, _ := authorUsecase.UpdateAuthor(ctx, authorId, authorRequest)
+
+		assert.Equal(t, authorResponse, actualAuthorResponse)
+	})
+
+	t.Run("should return ErrAuthorNotFound when updating non-existing author", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(false, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.UpdateAuthor(ctx, authorId, authorRequest)
+
+		assert.Equal(t, apperror.ErrAuthorNotFound{}, err)
+	})
+}
+
+func TestDeleteAuthorUsecase(t *testing.T) {
+	t.Run("should return the deleted author when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockAuthorRepo.On("HasActiveBooks", ctx, authorId).Return(false, nil)
+		mockAuthorRepo.On("DeleteAuthor", ctx, authorId).Return(&author, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		actualAuthorResponse, _ := authorUsecase.DeleteAuthor(ctx, authorId)
+
+		assert.Equal(t, authorResponse, actualAuthorResponse)
+	})
+
+	t.Run("should return ErrAuthorHasBooks when deleting author with active books", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockAuthorRepo.On("HasActiveBooks", ctx, authorId).Return(true, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.DeleteAuthor(ctx, authorId)
+
+		assert.Equal(t, apperror.ErrAuthorHasBooks{}, err)
+	})
+
+	t.Run("should return ErrAuthorNotFound when deleting non-existing author", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(false, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.DeleteAuthor(ctx, authorId)
+
+		assert.Equal(t, apperror.ErrAuthorNotFound{}, err)
+	})
+}
+
+func TestGetAuthorBooksUsecase(t *testing.T) {
+	t.Run("should return the author with their books when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockAuthorRepo.On("GetAuthorById", ctx, authorId).Return(&author, nil)
+		mockBookRepo.On("GetBooksByAuthorId", ctx, authorId).Return(authorBooks, nil)
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		actualResponse, _ := authorUsecase.GetAuthorBooks(ctx, authorId)
+
+		assert.Equal(t, authorBooksResponse, actualResponse)
+	})
+
+	t.Run("should return error when get books by author encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockAuthorRepo := new(mocks.AuthorRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockAuthorRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockAuthorRepo.On("GetAuthorById", ctx, authorId).Return(&author, nil)
+		mockBookRepo.On("GetBooksByAuthorId", ctx, authorId).Return(nil, errors.New("server error"))
+		authorUsecase := usecase.NewAuthorUsecase(mockAuthorRepo, mockBookRepo)
+
+		_, err := authorUsecase.GetAuthorBooks(ctx, authorId)
+
+		assert.NotNil(t, err)
+	})
+}
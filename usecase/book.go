@@ -5,13 +5,37 @@ import (
 	"archive_lib/dto"
 	"archive_lib/entity"
 	"archive_lib/repo"
+	"archive_lib/util/tracing"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 )
 
+const DefaultPageSize = 20
+
+var bookSortFields = map[string]bool{
+	"id":       true,
+	"title":    true,
+	"quantity": true,
+}
+
+type bookCursor struct {
+	Id       int    `json:"id"`
+	Title    string `json:"title,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+}
+
 type BookUsecase interface {
 	ListBooks(ctx context.Context) ([]*dto.BookResponse, error)
 	GetBooksByTitle(ctx context.Context, title string) ([]*dto.BookResponse, error)
+	ListBooksPage(ctx context.Context, bookListQuery *dto.BookListQuery) (*dto.BookPageResponse, error)
+	SearchBooks(ctx context.Context, bookSearchQuery *dto.BookSearchQuery) (*dto.BookPageResponse, error)
 	AddBook(ctx context.Context, bookRequest *dto.BookRequest) (*dto.BookResponse, error)
+	UpdateBook(ctx context.Context, id int, bookUpdateRequest *dto.BookUpdateRequest) (*dto.BookResponse, error)
+	PatchBook(ctx context.Context, id int, bookPatchRequest *dto.BookPatchRequest) (*dto.BookResponse, error)
+	DeleteBook(ctx context.Context, id int) (*dto.BookResponse, error)
+	RestoreBook(ctx context.Context, id int) (*dto.BookResponse, error)
 }
 
 type bookUsecaseImpl struct {
@@ -53,6 +77,7 @@ func (uc bookUsecaseImpl) convertBookToRes(book *entity.Book) *dto.BookResponse
 	}
 }
 
+// convertReqToBookPost maps a new book; the quantity seeds its copies.
 func (uc bookUsecaseImpl) convertReqToBookPost(dto *dto.BookRequest) *entity.BookPost {
 	return &entity.BookPost{
 		Title:       dto.Title,
@@ -63,7 +88,48 @@ func (uc bookUsecaseImpl) convertReqToBookPost(dto *dto.BookRequest) *entity.Boo
 	}
 }
 
+func (uc bookUsecaseImpl) convertUpdateReqToBookPost(id int, dto *dto.BookUpdateRequest) *entity.BookPost {
+	return &entity.BookPost{
+		Id:          id,
+		Title:       dto.Title,
+		Description: dto.Description,
+		Cover:       dto.Cover,
+		AuthorId:    *dto.AuthorId,
+	}
+}
+
+func (uc bookUsecaseImpl) mergePatchToBookPost(book *entity.Book, patch *dto.BookPatchRequest) *entity.BookPost {
+	bookPost := &entity.BookPost{
+		Id:          book.Id,
+		Title:       book.Title,
+		Description: book.Description,
+		Quantity:    book.Quantity,
+		Cover:       book.Cover,
+	}
+	if book.Author != nil {
+		bookPost.AuthorId = book.Author.Id
+	}
+
+	if patch.Title != nil {
+		bookPost.Title = *patch.Title
+	}
+	if patch.AuthorId != nil {
+		bookPost.AuthorId = *patch.AuthorId
+	}
+	if patch.Description != nil {
+		bookPost.Description = *patch.Description
+	}
+	if patch.Cover != nil {
+		bookPost.Cover = patch.Cover
+	}
+
+	return bookPost
+}
+
 func (uc bookUsecaseImpl) ListBooks(ctx context.Context) ([]*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.ListBooks")
+	defer span.End()
+
 	books, err := uc.bookRepo.ListBooks(ctx)
 	if err != nil {
 		return nil, err
@@ -76,6 +142,9 @@ func (uc bookUsecaseImpl) ListBooks(ctx context.Context) ([]*dto.BookResponse, e
 }
 
 func (uc bookUsecaseImpl) GetBooksByTitle(ctx context.Context, title string) ([]*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.GetBooksByTitle")
+	defer span.End()
+
 	books, err := uc.bookRepo.GetBooksByTitle(ctx, title)
 	if err != nil {
 		return nil, err
@@ -87,7 +156,199 @@ func (uc bookUsecaseImpl) GetBooksByTitle(ctx context.Context, title string) ([]
 	return booksResponse, nil
 }
 
+func (uc bookUsecaseImpl) encodeCursor(book *entity.Book) string {
+	raw, _ := json.Marshal(bookCursor{
+		Id:       book.Id,
+		Title:    book.Title,
+		Quantity: book.Quantity,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (uc bookUsecaseImpl) decodeCursor(token string) (*entity.BookCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, apperror.ErrInvalidCursor{}
+	}
+
+	var cursor bookCursor
+	err = json.Unmarshal(raw, &cursor)
+	if err != nil || cursor.Id <= 0 {
+		return nil, apperror.ErrInvalidCursor{}
+	}
+
+	return &entity.BookCursor{
+		Id:       cursor.Id,
+		Title:    cursor.Title,
+		Quantity: cursor.Quantity,
+	}, nil
+}
+
+func (uc bookUsecaseImpl) convertListQueryToBookQuery(bookListQuery *dto.BookListQuery) (*entity.BookQuery, error) {
+	query := &entity.BookQuery{
+		Title:         bookListQuery.Title,
+		AuthorId:      bookListQuery.AuthorId,
+		AuthorName:    bookListQuery.AuthorName,
+		Available:     bookListQuery.Available,
+		QuantityMin:   bookListQuery.QuantityMin,
+		QuantityMax:   bookListQuery.QuantityMax,
+		HasCover:      bookListQuery.HasCover,
+		CreatedAfter:  bookListQuery.CreatedAfter,
+		CreatedBefore: bookListQuery.CreatedBefore,
+		SortBy:        "id",
+		Limit:         DefaultPageSize,
+	}
+
+	if query.QuantityMin != nil && query.QuantityMax != nil && *query.QuantityMax < *query.QuantityMin {
+		return nil, apperror.ErrInvalidQuantityRange{}
+	}
+	if query.CreatedAfter != nil && query.CreatedBefore != nil && !query.CreatedBefore.After(*query.CreatedAfter) {
+		return nil, apperror.ErrInvalidDateRange{}
+	}
+
+	if bookListQuery.Sort != "" {
+		query.SortDesc = strings.HasPrefix(bookListQuery.Sort, "-")
+		query.SortBy = strings.TrimPrefix(bookListQuery.Sort, "-")
+		if !bookSortFields[query.SortBy] {
+			return nil, apperror.ErrInvalidSort{}
+		}
+	}
+
+	cursorMode := bookListQuery.Limit != nil || bookListQuery.Cursor != ""
+	pageMode := bookListQuery.Page != nil || bookListQuery.PerPage != nil
+	if cursorMode && pageMode {
+		return nil, apperror.ErrMixedPagination{}
+	}
+
+	if cursorMode {
+		if bookListQuery.Limit != nil {
+			query.Limit = *bookListQuery.Limit
+		}
+		if bookListQuery.Cursor != "" {
+			cursor, err := uc.decodeCursor(bookListQuery.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			query.Cursor = cursor
+		}
+		return query, nil
+	}
+
+	if bookListQuery.PerPage != nil {
+		query.Limit = *bookListQuery.PerPage
+	}
+	if bookListQuery.Page != nil {
+		query.Offset = (*bookListQuery.Page - 1) * query.Limit
+	}
+
+	return query, nil
+}
+
+func (uc bookUsecaseImpl) ListBooksPage(ctx context.Context, bookListQuery *dto.BookListQuery) (*dto.BookPageResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.ListBooksPage")
+	defer span.End()
+
+	query, err := uc.convertListQueryToBookQuery(bookListQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.bookRepo.CountBooks(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := query.Limit
+	pageQuery := *query
+	pageQuery.Limit = pageSize + 1
+	books, err := uc.bookRepo.ListBooksPage(ctx, &pageQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(books) > pageSize
+	if hasMore {
+		books = books[:pageSize]
+	}
+
+	booksResponse := []*dto.BookResponse{}
+	for _, book := range books {
+		booksResponse = append(booksResponse, uc.convertBookToRes(&book))
+	}
+
+	pagination := dto.PaginationResponse{Total: total}
+	if bookListQuery.Limit != nil || bookListQuery.Cursor != "" {
+		pagination.Limit = pageSize
+		if hasMore {
+			pagination.NextCursor = uc.encodeCursor(&books[len(books)-1])
+		}
+	} else {
+		pagination.Page = query.Offset/pageSize + 1
+		pagination.PerPage = pageSize
+	}
+
+	return &dto.BookPageResponse{
+		Data:       booksResponse,
+		Pagination: pagination,
+	}, nil
+}
+
+func (uc bookUsecaseImpl) convertSearchResultToRes(result *entity.BookSearchResult) *dto.BookResponse {
+	bookResponse := uc.convertBookToRes(&result.Book)
+	bookResponse.Rank = result.Rank
+	bookResponse.Highlight = &dto.BookHighlight{
+		Title:       result.TitleHighlight,
+		Description: result.DescriptionHighlight,
+	}
+	return bookResponse
+}
+
+func (uc bookUsecaseImpl) SearchBooks(ctx context.Context, bookSearchQuery *dto.BookSearchQuery) (*dto.BookPageResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.SearchBooks")
+	defer span.End()
+
+	search := &entity.BookSearch{
+		Query: bookSearchQuery.Q,
+		Limit: DefaultPageSize,
+	}
+	if bookSearchQuery.PerPage != nil {
+		search.Limit = *bookSearchQuery.PerPage
+	}
+	page := 1
+	if bookSearchQuery.Page != nil {
+		page = *bookSearchQuery.Page
+	}
+	search.Offset = (page - 1) * search.Limit
+
+	total, err := uc.bookRepo.CountSearchBooks(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := uc.bookRepo.SearchBooks(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	booksResponse := []*dto.BookResponse{}
+	for _, result := range results {
+		booksResponse = append(booksResponse, uc.convertSearchResultToRes(&result))
+	}
+
+	return &dto.BookPageResponse{
+		Data: booksResponse,
+		Pagination: dto.PaginationResponse{
+			Total:   total,
+			Page:    page,
+			PerPage: search.Limit,
+		},
+	}, nil
+}
+
 func (uc bookUsecaseImpl) AddBook(ctx context.Context, bookRequest *dto.BookRequest) (*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.AddBook")
+	defer span.End()
+
 	duplicate, err := uc.bookRepo.IsTitleExisted(ctx, bookRequest.Title)
 	if err != nil {
 		return nil, err
@@ -110,3 +371,124 @@ func (uc bookUsecaseImpl) AddBook(ctx context.Context, bookRequest *dto.BookRequ
 	}
 	return uc.convertBookToRes(addedBook), nil
 }
+
+func (uc bookUsecaseImpl) validateBookPost(ctx context.Context, bookPost *entity.BookPost) error {
+	duplicate, err := uc.bookRepo.IsTitleExistedForOther(ctx, bookPost.Title, bookPost.Id)
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		return apperror.ErrDuplicateTitle{}
+	}
+
+	found, err := uc.bookRepo.IsAuthorExisted(ctx, bookPost.AuthorId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperror.ErrAuthorNotFound{}
+	}
+
+	return nil
+}
+
+func (uc bookUsecaseImpl) UpdateBook(ctx context.Context, id int, bookUpdateRequest *dto.BookUpdateRequest) (*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.UpdateBook")
+	defer span.End()
+
+	found, err := uc.bookRepo.IsBookExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	bookPost := uc.convertUpdateReqToBookPost(id, bookUpdateRequest)
+	err = uc.validateBookPost(ctx, bookPost)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBook, err := uc.bookRepo.UpdateBook(ctx, bookPost)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertBookToRes(updatedBook), nil
+}
+
+func (uc bookUsecaseImpl) PatchBook(ctx context.Context, id int, bookPatchRequest *dto.BookPatchRequest) (*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.PatchBook")
+	defer span.End()
+
+	found, err := uc.bookRepo.IsBookExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	book, err := uc.bookRepo.GetBookById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	bookPost := uc.mergePatchToBookPost(book, bookPatchRequest)
+	err = uc.validateBookPost(ctx, bookPost)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBook, err := uc.bookRepo.UpdateBook(ctx, bookPost)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertBookToRes(updatedBook), nil
+}
+
+func (uc bookUsecaseImpl) DeleteBook(ctx context.Context, id int) (*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.DeleteBook")
+	defer span.End()
+
+	found, err := uc.bookRepo.IsBookExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	deletedBook, err := uc.bookRepo.DeleteBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertBookToRes(deletedBook), nil
+}
+
+func (uc bookUsecaseImpl) RestoreBook(ctx context.Context, id int) (*dto.BookResponse, error) {
+	ctx, span := tracing.Start(ctx, "BookUsecase.RestoreBook")
+	defer span.End()
+
+	active, err := uc.bookRepo.IsBookExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, apperror.ErrBookNotDeleted{}
+	}
+
+	found, err := uc.bookRepo.IsDeletedBookExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	restoredBook, err := uc.bookRepo.RestoreBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convertBookToRes(restoredBook), nil
+}
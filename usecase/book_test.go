@@ -6,9 +6,11 @@ import (
 	"archive_lib/entity"
 	"archive_lib/mocks"
 	"archive_lib/usecase"
+	"encoding/base64"
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -213,3 +215,459 @@ func TestAddBookUsecase(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestUpdateBookUsecase(t *testing.T) {
+	updateBookRequest := &dto.BookUpdateRequest{
+		Title:       "Test Book",
+		AuthorId:    &authorId,
+		Description: "Cool book",
+		Cover:       &cover,
+	}
+
+	t.Run("should return the updated book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		updatedBookPost := bookPost
+		updatedBookPost.Id = 1
+		updatedBookPost.Quantity = 0
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("IsTitleExistedForOther", ctx, "Test Book", 1).Return(false, nil)
+		mockBookRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockBookRepo.On("UpdateBook", ctx, &updatedBookPost).Return(&book, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualBookResponse, _ := bookUsecase.UpdateBook(ctx, 1, updateBookRequest)
+
+		assert.Equal(t, bookResponse, actualBookResponse)
+	})
+
+	t.Run("should return ErrBookNotFound when updating non-existing book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(false, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.UpdateBook(ctx, 1, updateBookRequest)
+
+		assert.Equal(t, apperror.ErrBookNotFound{}, err)
+	})
+
+	t.Run("should return ErrDuplicateTitle when updating book with title of another book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("IsTitleExistedForOther", ctx, "Test Book", 1).Return(true, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.UpdateBook(ctx, 1, updateBookRequest)
+
+		assert.Equal(t, apperror.ErrDuplicateTitle{}, err)
+	})
+
+	t.Run("should return ErrAuthorNotFound when updating book with non-existing author", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("IsTitleExistedForOther", ctx, "Test Book", 1).Return(false, nil)
+		mockBookRepo.On("IsAuthorExisted", ctx, authorId).Return(false, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.UpdateBook(ctx, 1, updateBookRequest)
+
+		assert.Equal(t, apperror.ErrAuthorNotFound{}, err)
+	})
+
+	t.Run("should return error when update book encounters server error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		updatedBookPost := bookPost
+		updatedBookPost.Id = 1
+		updatedBookPost.Quantity = 0
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("IsTitleExistedForOther", ctx, "Test Book", 1).Return(false, nil)
+		mockBookRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockBookRepo.On("UpdateBook", ctx, &updatedBookPost).Return(nil, errors.New("server error"))
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.UpdateBook(ctx, 1, updateBookRequest)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPatchBookUsecase(t *testing.T) {
+	t.Run("should return the patched book keeping unchanged fields when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		newDescription := "New description"
+		patchedBookPost := bookPost
+		patchedBookPost.Id = 1
+		patchedBookPost.Description = newDescription
+		patchedBook := book
+		patchedBook.Description = newDescription
+		patchedBookResponse := *bookResponse
+		patchedBookResponse.Description = newDescription
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("GetBookById", ctx, 1).Return(&bookWithAuthor, nil)
+		mockBookRepo.On("IsTitleExistedForOther", ctx, "Test Book", 1).Return(false, nil)
+		mockBookRepo.On("IsAuthorExisted", ctx, authorId).Return(true, nil)
+		mockBookRepo.On("UpdateBook", ctx, &patchedBookPost).Return(&patchedBook, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualBookResponse, _ := bookUsecase.PatchBook(ctx, 1, &dto.BookPatchRequest{Description: &newDescription})
+
+		assert.Equal(t, &patchedBookResponse, actualBookResponse)
+	})
+
+	t.Run("should return ErrBookNotFound when patching non-existing book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(false, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.PatchBook(ctx, 1, &dto.BookPatchRequest{})
+
+		assert.Equal(t, apperror.ErrBookNotFound{}, err)
+	})
+
+	t.Run("should return error when get book by id encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("GetBookById", ctx, 1).Return(nil, errors.New("server error"))
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.PatchBook(ctx, 1, &dto.BookPatchRequest{})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestDeleteBookUsecase(t *testing.T) {
+	t.Run("should return the deleted book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("DeleteBook", ctx, 1).Return(&book, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualBookResponse, _ := bookUsecase.DeleteBook(ctx, 1)
+
+		assert.Equal(t, bookResponse, actualBookResponse)
+	})
+
+	t.Run("should return ErrBookNotFound when deleting non-existing book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(false, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.DeleteBook(ctx, 1)
+
+		assert.Equal(t, apperror.ErrBookNotFound{}, err)
+	})
+
+	t.Run("should return error when delete book encounters server error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("DeleteBook", ctx, 1).Return(nil, errors.New("server error"))
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.DeleteBook(ctx, 1)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRestoreBookUsecase(t *testing.T) {
+	t.Run("should return the restored book when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(false, nil)
+		mockBookRepo.On("IsDeletedBookExisted", ctx, 1).Return(true, nil)
+		mockBookRepo.On("RestoreBook", ctx, 1).Return(&book, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualBookResponse, _ := bookUsecase.RestoreBook(ctx, 1)
+
+		assert.Equal(t, bookResponse, actualBookResponse)
+	})
+
+	t.Run("should return ErrBookNotDeleted when restoring an active book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.RestoreBook(ctx, 1)
+
+		assert.Equal(t, apperror.ErrBookNotDeleted{}, err)
+	})
+
+	t.Run("should return ErrBookNotFound when restoring non-existing book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(false, nil)
+		mockBookRepo.On("IsDeletedBookExisted", ctx, 1).Return(false, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.RestoreBook(ctx, 1)
+
+		assert.Equal(t, apperror.ErrBookNotFound{}, err)
+	})
+}
+
+func TestListBooksPageUsecase(t *testing.T) {
+	t.Run("should return the requested page with total when using page pagination", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		page, perPage := 2, 1
+		countQuery := &entity.BookQuery{SortBy: "title", SortDesc: true, Limit: 1, Offset: 1}
+		pageQuery := *countQuery
+		pageQuery.Limit = 2
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountBooks", ctx, countQuery).Return(3, nil)
+		mockBookRepo.On("ListBooksPage", ctx, &pageQuery).Return(booksWithAuthor, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualPage, _ := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Page: &page, PerPage: &perPage, Sort: "-title"})
+
+		assert.Equal(t, &dto.BookPageResponse{
+			Data:       booksWithAuthorResponse,
+			Pagination: dto.PaginationResponse{Total: 3, Page: 2, PerPage: 1},
+		}, actualPage)
+	})
+
+	t.Run("should return next cursor when more books are available in cursor pagination", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		limit := 1
+		nextBook := bookWithAuthor
+		nextBook.Id = 2
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountBooks", ctx, &entity.BookQuery{SortBy: "id", Limit: 1}).Return(2, nil)
+		mockBookRepo.On("ListBooksPage", ctx, &entity.BookQuery{SortBy: "id", Limit: 2}).Return([]entity.Book{bookWithAuthor, nextBook}, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualPage, _ := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Limit: &limit})
+
+		assert.Equal(t, booksWithAuthorResponse, actualPage.Data)
+		assert.Equal(t, 1, actualPage.Pagination.Limit)
+		assert.NotEmpty(t, actualPage.Pagination.NextCursor)
+	})
+
+	t.Run("should resume after the decoded cursor when cursor is given", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		limit := 1
+		cursor := base64.RawURLEncoding.EncodeToString([]byte(`{"id":1,"title":"Test Book","quantity":10}`))
+		countQuery := &entity.BookQuery{
+			SortBy: "title",
+			Limit:  1,
+			Cursor: &entity.BookCursor{Id: 1, Title: "Test Book", Quantity: quantity},
+		}
+		pageQuery := *countQuery
+		pageQuery.Limit = 2
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountBooks", ctx, countQuery).Return(2, nil)
+		mockBookRepo.On("ListBooksPage", ctx, &pageQuery).Return([]entity.Book{}, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualPage, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Limit: &limit, Cursor: cursor, Sort: "title"})
+
+		assert.Nil(t, err)
+		assert.Empty(t, actualPage.Data)
+		assert.Empty(t, actualPage.Pagination.NextCursor)
+	})
+
+	t.Run("should return ErrInvalidSort when sorting by unsupported field", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Sort: "description"})
+
+		assert.Equal(t, apperror.ErrInvalidSort{}, err)
+	})
+
+	t.Run("should return ErrMixedPagination when combining cursor and page pagination", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		limit, page := 1, 1
+		mockBookRepo := new(mocks.BookRepo)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Limit: &limit, Page: &page})
+
+		assert.Equal(t, apperror.ErrMixedPagination{}, err)
+	})
+
+	t.Run("should return ErrInvalidCursor when cursor cannot be decoded", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Cursor: "not a cursor"})
+
+		assert.Equal(t, apperror.ErrInvalidCursor{}, err)
+	})
+
+	t.Run("should return error when count books encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountBooks", ctx, &entity.BookQuery{SortBy: "id", Limit: usecase.DefaultPageSize}).Return(0, errors.New("server error"))
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{Sort: "id"})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestListBooksPageFilterUsecase(t *testing.T) {
+	t.Run("should pass every filter to the repository when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		available, hasCover := true, true
+		quantityMin, quantityMax := 1, 10
+		createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		createdBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		bookListQuery := &dto.BookListQuery{
+			AuthorId:      &authorId,
+			AuthorName:    "poet",
+			Available:     &available,
+			QuantityMin:   &quantityMin,
+			QuantityMax:   &quantityMax,
+			HasCover:      &hasCover,
+			CreatedAfter:  &createdAfter,
+			CreatedBefore: &createdBefore,
+		}
+		countQuery := &entity.BookQuery{
+			AuthorId:      &authorId,
+			AuthorName:    "poet",
+			Available:     &available,
+			QuantityMin:   &quantityMin,
+			QuantityMax:   &quantityMax,
+			HasCover:      &hasCover,
+			CreatedAfter:  &createdAfter,
+			CreatedBefore: &createdBefore,
+			SortBy:        "id",
+			Limit:         usecase.DefaultPageSize,
+		}
+		pageQuery := *countQuery
+		pageQuery.Limit = usecase.DefaultPageSize + 1
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountBooks", ctx, countQuery).Return(1, nil)
+		mockBookRepo.On("ListBooksPage", ctx, &pageQuery).Return(booksWithAuthor, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualPage, _ := bookUsecase.ListBooksPage(ctx, bookListQuery)
+
+		assert.Equal(t, booksWithAuthorResponse, actualPage.Data)
+		assert.Equal(t, 1, actualPage.Pagination.Total)
+	})
+
+	t.Run("should return ErrInvalidQuantityRange when quantity_max is less than quantity_min", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		quantityMin, quantityMax := 5, 1
+		mockBookRepo := new(mocks.BookRepo)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{QuantityMin: &quantityMin, QuantityMax: &quantityMax})
+
+		assert.Equal(t, apperror.ErrInvalidQuantityRange{}, err)
+	})
+
+	t.Run("should return ErrInvalidDateRange when created_before is not after created_after", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		createdAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		createdBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockBookRepo := new(mocks.BookRepo)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.ListBooksPage(ctx, &dto.BookListQuery{CreatedAfter: &createdAfter, CreatedBefore: &createdBefore})
+
+		assert.Equal(t, apperror.ErrInvalidDateRange{}, err)
+	})
+}
+
+func TestSearchBooksUsecase(t *testing.T) {
+	t.Run("should return ranked books with highlights when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		search := &entity.BookSearch{Query: "cool", Limit: usecase.DefaultPageSize}
+		searchResults := []entity.BookSearchResult{{
+			Book:                 bookWithAuthor,
+			Rank:                 0.5,
+			TitleHighlight:       "Test Book",
+			DescriptionHighlight: "<mark>Cool</mark> book",
+		}}
+		expectedBookResponse := *bookWithAuthorResponse
+		expectedBookResponse.Rank = 0.5
+		expectedBookResponse.Highlight = &dto.BookHighlight{
+			Title:       "Test Book",
+			Description: "<mark>Cool</mark> book",
+		}
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountSearchBooks", ctx, search).Return(1, nil)
+		mockBookRepo.On("SearchBooks", ctx, search).Return(searchResults, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualPage, _ := bookUsecase.SearchBooks(ctx, &dto.BookSearchQuery{Q: "cool"})
+
+		assert.Equal(t, &dto.BookPageResponse{
+			Data:       []*dto.BookResponse{&expectedBookResponse},
+			Pagination: dto.PaginationResponse{Total: 1, Page: 1, PerPage: usecase.DefaultPageSize},
+		}, actualPage)
+	})
+
+	t.Run("should apply page offset when page is given", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		page, perPage := 3, 5
+		search := &entity.BookSearch{Query: "cool", Limit: 5, Offset: 10}
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountSearchBooks", ctx, search).Return(11, nil)
+		mockBookRepo.On("SearchBooks", ctx, search).Return([]entity.BookSearchResult{}, nil)
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		actualPage, _ := bookUsecase.SearchBooks(ctx, &dto.BookSearchQuery{Q: "cool", Page: &page, PerPage: &perPage})
+
+		assert.Equal(t, 3, actualPage.Pagination.Page)
+		assert.Empty(t, actualPage.Data)
+	})
+
+	t.Run("should return error when search books encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		search := &entity.BookSearch{Query: "cool", Limit: usecase.DefaultPageSize}
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("CountSearchBooks", ctx, search).Return(1, nil)
+		mockBookRepo.On("SearchBooks", ctx, search).Return(nil, errors.New("server error"))
+		bookUsecase := usecase.NewBookUsecase(mockBookRepo)
+
+		_, err := bookUsecase.SearchBooks(ctx, &dto.BookSearchQuery{Q: "cool"})
+
+		assert.NotNil(t, err)
+	})
+}
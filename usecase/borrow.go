@@ -5,39 +5,58 @@ import (
 	"archive_lib/dto"
 	"archive_lib/entity"
 	"archive_lib/repo"
+	"archive_lib/util/metrics"
+	"archive_lib/util/tracing"
 	"context"
+	"errors"
+	"fmt"
 )
 
+type LoanConfig struct {
+	LoanDays       int
+	MaxRenewals    int
+	HoldPickupDays int
+	MaxActiveLoans map[string]int
+	DailyFine      int64
+	FineThreshold  int64
+}
+
 type BorrowUsecase interface {
+	ListBorrows(ctx context.Context, query *dto.BorrowListQuery) (*dto.BorrowPageResponse, error)
+	ListUserBorrows(ctx context.Context, userId int, query *dto.BorrowListQuery) (*dto.BorrowPageResponse, error)
+	GetBorrow(ctx context.Context, id int, userId int, role string) (*dto.BorrowResponse, error)
 	Record(ctx context.Context, borrowRequest *dto.BorrowRequest) (*dto.BorrowResponse, error)
+	Checkout(ctx context.Context, borrowRequest *dto.BorrowRequest, staffId int) (*dto.BorrowResponse, error)
 	Return(ctx context.Context, returnRequest *dto.ReturnRequest) (*dto.BorrowResponse, error)
+	BatchRecord(ctx context.Context, batchRequest *dto.BatchBorrowRequest) (*dto.BatchResponse, error)
+	BatchReturn(ctx context.Context, batchRequest *dto.BatchReturnRequest) (*dto.BatchResponse, error)
+	Renew(ctx context.Context, id int, userId int) (*dto.BorrowResponse, error)
+	UpdateStatus(ctx context.Context, id int, staffId int, statusRequest *dto.BorrowStatusRequest) (*dto.BorrowResponse, error)
 }
 
 type borrowUsecaseImpl struct {
 	borrowRepo repo.BorrowRepo
 	bookRepo   repo.BookRepo
+	userRepo   repo.UserRepo
+	copyRepo   repo.CopyRepo
+	holdRepo   repo.HoldRepo
+	fineRepo   repo.FineRepo
 	txRepo     repo.TransactionRepo
+	policy     BorrowPolicy
+	loanConfig LoanConfig
 }
 
-func NewBorrowUsecase(borrowRepo repo.BorrowRepo, bookRepo repo.BookRepo, txRepo repo.TransactionRepo) borrowUsecaseImpl {
+func NewBorrowUsecase(borrowRepo repo.BorrowRepo, bookRepo repo.BookRepo, userRepo repo.UserRepo, copyRepo repo.CopyRepo, holdRepo repo.HoldRepo, fineRepo repo.FineRepo, txRepo repo.TransactionRepo, policy BorrowPolicy, loanConfig LoanConfig) borrowUsecaseImpl {
 	return borrowUsecaseImpl{
 		borrowRepo: borrowRepo,
 		bookRepo:   bookRepo,
+		userRepo:   userRepo,
+		copyRepo:   copyRepo,
+		holdRepo:   holdRepo,
+		fineRepo:   fineRepo,
 		txRepo:     txRepo,
-	}
-}
-
-func (uc borrowUsecaseImpl) convertBorrowReqToBorrow(dto *dto.BorrowRequest) *entity.Borrow {
-	return &entity.Borrow{
-		BookId: *dto.BookId,
-		UserId: dto.UserId,
-	}
-}
-
-func (uc borrowUsecaseImpl) convertReturnReqToBorrow(dto *dto.ReturnRequest) *entity.Borrow {
-	return &entity.Borrow{
-		Id:     *dto.Id,
-		UserId: dto.UserId,
+		policy:     policy,
+		loanConfig: loanConfig,
 	}
 }
 
@@ -46,8 +65,13 @@ func (uc borrowUsecaseImpl) convertBorrowToBorrowRes(borrow *entity.Borrow) *dto
 		Id:            borrow.Id,
 		UserId:        borrow.UserId,
 		BookId:        borrow.BookId,
+		CopyId:        borrow.CopyId,
 		Status:        borrow.Status,
 		BorrowingDate: borrow.BorrowingDate,
+		DueDate:       borrow.DueDate,
+		DaysOverdue:   borrow.DaysOverdue,
+		RenewalCount:  borrow.RenewalCount,
+		CheckedOutBy:  borrow.CheckedOutBy,
 	}
 }
 
@@ -56,97 +80,708 @@ func (uc borrowUsecaseImpl) convertBorrowToReturnRes(borrow *entity.Borrow) *dto
 		Id:            borrow.Id,
 		UserId:        borrow.UserId,
 		BookId:        borrow.BookId,
+		CopyId:        borrow.CopyId,
 		Status:        borrow.Status,
 		BorrowingDate: borrow.BorrowingDate,
 		ReturningDate: &borrow.ReturningDate,
+		DueDate:       borrow.DueDate,
+		DaysOverdue:   borrow.DaysOverdue,
+		RenewalCount:  borrow.RenewalCount,
+		CheckedOutBy:  borrow.CheckedOutBy,
+	}
+}
+
+func (uc borrowUsecaseImpl) convertBorrowToRes(borrow *entity.Borrow) *dto.BorrowResponse {
+	if borrow.ReturningDate.IsZero() {
+		return uc.convertBorrowToBorrowRes(borrow)
+	}
+	return uc.convertBorrowToReturnRes(borrow)
+}
+
+func (uc borrowUsecaseImpl) convertListQueryToBorrowQuery(listQuery *dto.BorrowListQuery) (*entity.BorrowQuery, error) {
+	query := &entity.BorrowQuery{
+		UserId:         listQuery.UserId,
+		BookId:         listQuery.BookId,
+		Status:         listQuery.Status,
+		BorrowedAfter:  listQuery.BorrowedAfter,
+		BorrowedBefore: listQuery.BorrowedBefore,
+		Limit:          DefaultPageSize,
+	}
+
+	if query.BorrowedAfter != nil && query.BorrowedBefore != nil && !query.BorrowedBefore.After(*query.BorrowedAfter) {
+		return nil, apperror.ErrInvalidBorrowDateRange{}
+	}
+
+	if listQuery.PerPage != nil {
+		query.Limit = *listQuery.PerPage
+	}
+	if listQuery.Page != nil {
+		query.Offset = (*listQuery.Page - 1) * query.Limit
+	}
+
+	return query, nil
+}
+
+func (uc borrowUsecaseImpl) listBorrowsPage(ctx context.Context, query *entity.BorrowQuery) (*dto.BorrowPageResponse, error) {
+	total, err := uc.borrowRepo.CountBorrows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	borrows, err := uc.borrowRepo.ListBorrows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	borrowResponses := []dto.BorrowResponse{}
+	for i := range borrows {
+		borrowResponses = append(borrowResponses, *uc.convertBorrowToRes(&borrows[i]))
+	}
+
+	return &dto.BorrowPageResponse{
+		Data: borrowResponses,
+		Pagination: dto.PaginationResponse{
+			Total:   total,
+			Page:    query.Offset/query.Limit + 1,
+			PerPage: query.Limit,
+		},
+	}, nil
+}
+
+func (uc borrowUsecaseImpl) ListBorrows(ctx context.Context, listQuery *dto.BorrowListQuery) (*dto.BorrowPageResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.ListBorrows")
+	defer span.End()
+
+	query, err := uc.convertListQueryToBorrowQuery(listQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.listBorrowsPage(ctx, query)
+}
+
+func (uc borrowUsecaseImpl) ListUserBorrows(ctx context.Context, userId int, listQuery *dto.BorrowListQuery) (*dto.BorrowPageResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.ListUserBorrows")
+	defer span.End()
+
+	query, err := uc.convertListQueryToBorrowQuery(listQuery)
+	if err != nil {
+		return nil, err
+	}
+	query.UserId = &userId
+
+	return uc.listBorrowsPage(ctx, query)
+}
+
+func (uc borrowUsecaseImpl) GetBorrow(ctx context.Context, id int, userId int, role string) (*dto.BorrowResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.GetBorrow")
+	defer span.End()
+
+	found, err := uc.borrowRepo.IsBorrowExisted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBorrowNotFound{}
+	}
+
+	borrow, err := uc.borrowRepo.GetBorrowById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// members may only look up their own records; the records of others are not revealed to exist
+	if role != entity.RoleLibrarian && borrow.UserId != userId {
+		return nil, apperror.ErrBorrowNotFound{}
+	}
+
+	return uc.convertBorrowToRes(borrow), nil
+}
+
+// scanCopy looks up the copy behind a scanned barcode.
+func (uc borrowUsecaseImpl) scanCopy(txCtx context.Context, barcode string) (*entity.BookCopy, error) {
+	found, err := uc.copyRepo.IsBarcodeExisted(txCtx, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrCopyNotFound{}
+	}
+
+	return uc.copyRepo.GetCopyByBarcode(txCtx, barcode)
+}
+
+func (uc borrowUsecaseImpl) record(txCtx context.Context, borrowRequest *dto.BorrowRequest) (*dto.BorrowResponse, error) {
+	var scanned *entity.BookCopy
+	var bookId int
+	if borrowRequest.Barcode != "" {
+		var err error
+		scanned, err = uc.scanCopy(txCtx, borrowRequest.Barcode)
+		if err != nil {
+			return nil, err
+		}
+		bookId = scanned.BookId
+	} else {
+		bookId = *borrowRequest.BookId
 	}
+
+	found, err := uc.bookRepo.IsBookExisted(txCtx, bookId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	borrow := &entity.Borrow{
+		BookId:       bookId,
+		UserId:       borrowRequest.UserId,
+		CheckedOutBy: borrowRequest.CheckedOutBy,
+	}
+	err = uc.policy.Check(txCtx, borrow)
+	if err != nil {
+		return nil, err
+	}
+
+	err = releaseExpiredHolds(txCtx, uc.holdRepo, uc.copyRepo, bookId, uc.loanConfig.HoldPickupDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// the copy set aside for the user's ready hold, if any
+	reservedCopyId, err := uc.holdRepo.FulfilHold(txCtx, bookId, borrowRequest.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case scanned == nil && reservedCopyId != 0:
+		borrow.CopyId = reservedCopyId
+	case scanned == nil:
+		borrow.CopyId, err = uc.copyRepo.TakeAvailableCopy(txCtx, bookId)
+		if err != nil {
+			return nil, err
+		}
+		if borrow.CopyId == 0 {
+			return nil, apperror.ErrEmptyStock{}
+		}
+	case scanned.Id == reservedCopyId:
+		borrow.CopyId = scanned.Id
+	default:
+		if scanned.Status != entity.CopyStatusAvailable {
+			return nil, apperror.ErrCopyUnavailable{}
+		}
+		borrow.CopyId = scanned.Id
+
+		// the user took another copy off the shelf, so the one set aside for them goes to the next hold
+		if reservedCopyId != 0 {
+			err = allocateCopy(txCtx, uc.holdRepo, uc.copyRepo, bookId, reservedCopyId, uc.loanConfig.HoldPickupDays)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	borrowed, err := uc.borrowRepo.Record(txCtx, borrow, uc.loanConfig.LoanDays)
+	if err != nil {
+		return nil, err
+	}
+	err = uc.copyRepo.SetCopyStatus(txCtx, borrow.CopyId, entity.CopyStatusBorrowed)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.convertBorrowToBorrowRes(borrowed), nil
 }
 
 func (uc borrowUsecaseImpl) Record(ctx context.Context, borrowRequest *dto.BorrowRequest) (*dto.BorrowResponse, error) {
-	var borrow *entity.Borrow
-	var borrowed *entity.Borrow
-	bookId := *borrowRequest.BookId
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.Record")
+	defer span.End()
+
+	var borrowResponse *dto.BorrowResponse
 
 	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
-		found, err := uc.bookRepo.IsBookExisted(txCtx, bookId)
+		var err error
+		borrowResponse, err = uc.record(txCtx, borrowRequest)
+		return err
+	})
+	observeBorrow(err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return borrowResponse, nil
+}
+
+// Checkout lends a book to the member named in the request on behalf of a librarian at the desk,
+// under the same stock and policy checks as Record. The borrower should be a member, not a librarian.
+func (uc borrowUsecaseImpl) Checkout(ctx context.Context, borrowRequest *dto.BorrowRequest, staffId int) (*dto.BorrowResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.Checkout")
+	defer span.End()
+
+	var borrowResponse *dto.BorrowResponse
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.userRepo.IsUserExisted(txCtx, borrowRequest.UserId)
 		if err != nil {
 			return err
 		}
 		if !found {
-			return apperror.ErrBookNotFound{}
+			return apperror.ErrUserNotFound{}
 		}
 
-		ok, err := uc.bookRepo.IsStockAvailable(txCtx, bookId)
+		user, err := uc.userRepo.GetUserById(txCtx, borrowRequest.UserId)
 		if err != nil {
 			return err
 		}
-		if !ok {
-			return apperror.ErrEmptyStock{}
+		if user.Role != entity.RoleMember {
+			return apperror.ErrBorrowerNotMember{}
 		}
 
-		borrow = uc.convertBorrowReqToBorrow(borrowRequest)
-		borrowed, err = uc.borrowRepo.Record(txCtx, borrow)
+		borrowRequest.CheckedOutBy = &staffId
+		borrowResponse, err = uc.record(txCtx, borrowRequest)
+		return err
+	})
+	observeBorrow(err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return borrowResponse, nil
+}
+
+// chargeOverdueFine charges the daily fine for every day the loan was kept past its due date.
+func (uc borrowUsecaseImpl) chargeOverdueFine(txCtx context.Context, borrow *entity.Borrow) (int64, error) {
+	fineAmount := int64(borrow.DaysOverdue) * uc.loanConfig.DailyFine
+	if fineAmount <= 0 {
+		return 0, nil
+	}
+
+	_, err := uc.fineRepo.AddFineEntry(txCtx, &entity.FineEntry{
+		UserId:   borrow.UserId,
+		BorrowId: &borrow.Id,
+		Kind:     entity.FineKindCharge,
+		Amount:   fineAmount,
+		Note:     fmt.Sprintf("Returned %d day(s) late", borrow.DaysOverdue),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return fineAmount, nil
+}
+
+func (uc borrowUsecaseImpl) returnBorrow(txCtx context.Context, returnRequest *dto.ReturnRequest) (*dto.BorrowResponse, error) {
+	var id int
+	if returnRequest.Barcode != "" {
+		scanned, err := uc.scanCopy(txCtx, returnRequest.Barcode)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		err = uc.bookRepo.DecrementStock(txCtx, bookId)
+		id, err = uc.borrowRepo.GetActiveBorrowIdByCopy(txCtx, scanned.Id)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if id == 0 {
+			return nil, apperror.ErrBorrowNotFound{}
+		}
+	} else {
+		id = *returnRequest.Id
+	}
+	user_id := returnRequest.UserId
+
+	authorized, err := uc.borrowRepo.IsUserAuthorized(txCtx, id, user_id)
+	if err != nil {
+		return nil, err
+	}
+	if !authorized {
+		return nil, apperror.ErrReturnUnauthorized{}
+	}
+
+	found, err := uc.borrowRepo.IsBorrowExisted(txCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBorrowNotFound{}
+	}
+
+	hasReturned, err := uc.borrowRepo.IsReturned(txCtx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hasReturned {
+		return nil, apperror.ErrAlreadyReturned{}
+	}
+
+	borrowed, err := uc.borrowRepo.Return(txCtx, &entity.Borrow{Id: id, UserId: user_id})
+	if err != nil {
+		return nil, err
+	}
+	err = allocateCopy(txCtx, uc.holdRepo, uc.copyRepo, borrowed.BookId, borrowed.CopyId, uc.loanConfig.HoldPickupDays)
+	if err != nil {
+		return nil, err
+	}
+
+	fineAmount, err := uc.chargeOverdueFine(txCtx, borrowed)
+	if err != nil {
+		return nil, err
+	}
+
+	returnResponse := uc.convertBorrowToReturnRes(borrowed)
+	returnResponse.FineAmount = fineAmount
+
+	return returnResponse, nil
+}
+
+func (uc borrowUsecaseImpl) Return(ctx context.Context, returnRequest *dto.ReturnRequest) (*dto.BorrowResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.Return")
+	defer span.End()
+
+	var returnResponse *dto.BorrowResponse
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		returnResponse, err = uc.returnBorrow(txCtx, returnRequest)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	metrics.BooksReturned.Inc()
+
+	return returnResponse, nil
+}
+
+// rejectionReason names the borrow rule an error stands for, or returns "" for any other error.
+func rejectionReason(err error) string {
+	switch err.(type) {
+	case apperror.ErrEmptyStock:
+		return metrics.ReasonEmptyStock
+	case apperror.ErrCopyUnavailable:
+		return metrics.ReasonCopyUnavailable
+	case apperror.ErrDuplicateLoan:
+		return metrics.ReasonDuplicateLoan
+	case apperror.ErrHasOverdueLoans:
+		return metrics.ReasonOverdueLoans
+	case apperror.ErrLoanLimitReached:
+		return metrics.ReasonLoanLimit
+	case apperror.ErrUnpaidFines:
+		return metrics.ReasonUnpaidFines
+	}
+	return ""
+}
+
+// observeBorrow counts the outcome of a single borrow: a book lent, or an attempt rejected by a borrow rule.
+func observeBorrow(err error) {
+	if err == nil {
+		metrics.BooksBorrowed.Inc()
+		return
+	}
+	if reason := rejectionReason(err); reason != "" {
+		metrics.BorrowsRejected.WithLabelValues(reason).Inc()
+	}
+}
+
+// isBatchItemError tells the errors that fail a single batch item from those that fail the whole batch.
+func isBatchItemError(err error) bool {
+	switch err.(type) {
+	case apperror.ErrBookNotFound, apperror.ErrEmptyStock, apperror.ErrDuplicateLoan, apperror.ErrHasOverdueLoans,
+		apperror.ErrLoanLimitReached, apperror.ErrUnpaidFines, apperror.ErrBorrowNotFound,
+		apperror.ErrReturnUnauthorized, apperror.ErrAlreadyReturned, apperror.ErrCopyNotFound, apperror.ErrCopyUnavailable:
+		return true
+	}
+	return false
+}
+
+var errBatchAborted = errors.New("batch aborted")
+
+// runBatch runs every item in its own savepoint of a single transaction. In atomic mode the first
+// failing item rolls the whole batch back and the remaining items are skipped.
+func (uc borrowUsecaseImpl) runBatch(ctx context.Context, mode string, items []dto.BatchItemResponse, itemFn func(txCtx context.Context, i int) (*dto.BorrowResponse, error)) (*dto.BatchResponse, error) {
+	if mode == "" {
+		mode = entity.BatchModeAtomic
+	}
+	for i := range items {
+		items[i].Status = entity.BatchItemSkipped
+	}
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		for i := range items {
+			var itemResponse *dto.BorrowResponse
+			err := uc.txRepo.WithinSavepoint(txCtx, func(spCtx context.Context) error {
+				var err error
+				itemResponse, err = itemFn(spCtx, i)
+				return err
+			})
+			if err != nil {
+				if !isBatchItemError(err) {
+					return err
+				}
+				items[i].Status = entity.BatchItemFailed
+				items[i].Error = err.Error()
+				if mode == entity.BatchModeAtomic {
+					return errBatchAborted
+				}
+				continue
+			}
+			items[i].Status = entity.BatchItemSucceeded
+			items[i].Data = itemResponse
 		}
 		return nil
 	})
 
+	if err != nil && err != errBatchAborted {
+		return nil, err
+	}
+
+	batchResponse := &dto.BatchResponse{
+		Mode:      mode,
+		Committed: err == nil,
+		Items:     items,
+	}
+	for i := range items {
+		switch items[i].Status {
+		case entity.BatchItemSucceeded:
+			if !batchResponse.Committed {
+				items[i].Status = entity.BatchItemRolledBack
+				items[i].Data = nil
+				continue
+			}
+			batchResponse.Succeeded++
+		case entity.BatchItemFailed:
+			batchResponse.Failed++
+		}
+	}
+
+	return batchResponse, nil
+}
+
+func (uc borrowUsecaseImpl) BatchRecord(ctx context.Context, batchRequest *dto.BatchBorrowRequest) (*dto.BatchResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.BatchRecord")
+	defer span.End()
+
+	items := make([]dto.BatchItemResponse, len(batchRequest.BookIds))
+	for i, bookId := range batchRequest.BookIds {
+		items[i].BookId = bookId
+	}
+
+	batchResponse, err := uc.runBatch(ctx, batchRequest.Mode, items, func(txCtx context.Context, i int) (*dto.BorrowResponse, error) {
+		borrowResponse, err := uc.record(txCtx, &dto.BorrowRequest{
+			BookId: &batchRequest.BookIds[i],
+			UserId: batchRequest.UserId,
+		})
+		if reason := rejectionReason(err); reason != "" {
+			metrics.BorrowsRejected.WithLabelValues(reason).Inc()
+		}
+		return borrowResponse, err
+	})
 	if err != nil {
 		return nil, err
 	}
+	metrics.BooksBorrowed.Add(float64(batchResponse.Succeeded))
 
-	return uc.convertBorrowToBorrowRes(borrowed), nil
+	return batchResponse, nil
 }
 
-func (uc borrowUsecaseImpl) Return(ctx context.Context, returnRequest *dto.ReturnRequest) (*dto.BorrowResponse, error) {
-	var borrow *entity.Borrow
-	var borrowed *entity.Borrow
-	id := *returnRequest.Id
-	user_id := returnRequest.UserId
+func (uc borrowUsecaseImpl) BatchReturn(ctx context.Context, batchRequest *dto.BatchReturnRequest) (*dto.BatchResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.BatchReturn")
+	defer span.End()
+
+	items := make([]dto.BatchItemResponse, len(batchRequest.Ids))
+	for i, id := range batchRequest.Ids {
+		items[i].Id = id
+	}
+
+	batchResponse, err := uc.runBatch(ctx, batchRequest.Mode, items, func(txCtx context.Context, i int) (*dto.BorrowResponse, error) {
+		return uc.returnBorrow(txCtx, &dto.ReturnRequest{
+			Id:     &batchRequest.Ids[i],
+			UserId: batchRequest.UserId,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.BooksReturned.Add(float64(batchResponse.Succeeded))
+
+	return batchResponse, nil
+}
+
+func (uc borrowUsecaseImpl) Renew(ctx context.Context, id int, userId int) (*dto.BorrowResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.Renew")
+	defer span.End()
+
+	var renewed *entity.Borrow
 
 	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
-		authorized, err := uc.borrowRepo.IsUserAuthorized(txCtx, id, user_id)
+		found, err := uc.borrowRepo.IsBorrowExisted(txCtx, id)
 		if err != nil {
 			return err
 		}
-		if !authorized {
-			return apperror.ErrReturnUnauthorized{}
+		if !found {
+			return apperror.ErrBorrowNotFound{}
 		}
 
-		found, err := uc.borrowRepo.IsBorrowExisted(txCtx, id)
+		authorized, err := uc.borrowRepo.IsUserAuthorized(txCtx, id, userId)
 		if err != nil {
 			return err
 		}
-		if !found {
+		if !authorized {
 			return apperror.ErrBorrowNotFound{}
 		}
 
-		hasReturned, err := uc.borrowRepo.IsReturned(txCtx, id)
+		current, err := uc.borrowRepo.GetBorrowById(txCtx, id)
 		if err != nil {
 			return err
 		}
-		if hasReturned {
+		if !current.ReturningDate.IsZero() {
 			return apperror.ErrAlreadyReturned{}
 		}
+		// a loan claimed returned is under investigation, not out with the member; an overdue loan is still borrowed
+		if current.Status != entity.BorrowStatusBorrowed && current.Status != entity.BorrowStatusOverdue {
+			return apperror.ErrLoanNotRenewable{}
+		}
+		if current.RenewalCount >= uc.loanConfig.MaxRenewals {
+			return apperror.ErrRenewalLimitReached{}
+		}
+
+		hasHolds, err := uc.holdRepo.HasWaitingHolds(txCtx, current.BookId)
+		if err != nil {
+			return err
+		}
+		if hasHolds {
+			return apperror.ErrBookHasHolds{}
+		}
+
+		renewed, err = uc.borrowRepo.Renew(txCtx, &entity.Borrow{Id: id, UserId: userId}, uc.loanConfig.LoanDays)
+		if err != nil {
+			return err
+		}
+
+		return uc.borrowRepo.AddRenewal(txCtx, &entity.Renewal{
+			BorrowId:        id,
+			PreviousDueDate: current.DueDate,
+			NewDueDate:      renewed.DueDate,
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.convertBorrowToBorrowRes(renewed), nil
+}
+
+// reshelveReturnedCopy puts the copy of a loan marked returned back into circulation, unless it has moved on
+// since: a lost or damaged copy may have been put back on the shelf and lent to someone else meanwhile.
+func (uc borrowUsecaseImpl) reshelveReturnedCopy(txCtx context.Context, borrow *entity.Borrow) error {
+	bookCopy, err := uc.copyRepo.GetCopyById(txCtx, borrow.CopyId)
+	if err != nil {
+		return err
+	}
+
+	switch bookCopy.Status {
+	case entity.CopyStatusLost, entity.CopyStatusDamaged:
+	case entity.CopyStatusBorrowed:
+		// the loan is closed by now, so any loan still holding the copy belongs to another member
+		activeId, err := uc.borrowRepo.GetActiveBorrowIdByCopy(txCtx, borrow.CopyId)
+		if err != nil {
+			return err
+		}
+		if activeId != 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return allocateCopy(txCtx, uc.holdRepo, uc.copyRepo, borrow.BookId, borrow.CopyId, uc.loanConfig.HoldPickupDays)
+}
+
+// replacementNotes describes the replacement charge of the statuses that can carry one.
+var replacementNotes = map[string]string{
+	entity.BorrowStatusLost:            "Replacement for lost copy",
+	entity.BorrowStatusDamagedOnReturn: "Replacement for damaged copy",
+}
+
+func (uc borrowUsecaseImpl) UpdateStatus(ctx context.Context, id int, staffId int, statusRequest *dto.BorrowStatusRequest) (*dto.BorrowResponse, error) {
+	ctx, span := tracing.Start(ctx, "BorrowUsecase.UpdateStatus")
+	defer span.End()
+
+	var borrowResponse *dto.BorrowResponse
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.borrowRepo.IsBorrowExisted(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrBorrowNotFound{}
+		}
 
-		borrow = uc.convertReturnReqToBorrow(returnRequest)
-		borrowed, err = uc.borrowRepo.Return(txCtx, borrow)
+		current, err := uc.borrowRepo.GetBorrowById(txCtx, id)
 		if err != nil {
 			return err
 		}
-		bookId, err := uc.borrowRepo.GetBookByBorrowId(txCtx, id)
+		if !current.CanTransitionTo(statusRequest.Status) {
+			return apperror.ErrInvalidBorrowTransition{}
+		}
+
+		replacementNote, chargeable := replacementNotes[statusRequest.Status]
+		if statusRequest.ReplacementCharge > 0 && !chargeable {
+			return apperror.ErrReplacementChargeNotAllowed{}
+		}
+
+		updated, err := uc.borrowRepo.UpdateStatus(txCtx, &entity.Borrow{Id: id, Status: statusRequest.Status})
 		if err != nil {
 			return err
 		}
-		err = uc.bookRepo.IncrementStock(txCtx, bookId)
+
+		// a claimed return leaves the copy out until it turns up on the shelf
+		switch statusRequest.Status {
+		case entity.BorrowStatusReturned:
+			err = uc.reshelveReturnedCopy(txCtx, updated)
+		case entity.BorrowStatusLost:
+			err = uc.copyRepo.SetCopyStatus(txCtx, updated.CopyId, entity.CopyStatusLost)
+		case entity.BorrowStatusDamagedOnReturn:
+			err = uc.copyRepo.SetCopyStatus(txCtx, updated.CopyId, entity.CopyStatusDamaged)
+		}
 		if err != nil {
 			return err
 		}
+
+		var fineAmount int64
+		if current.IsOpen() && entity.ClosesLoan(statusRequest.Status) {
+			fineAmount, err = uc.chargeOverdueFine(txCtx, updated)
+			if err != nil {
+				return err
+			}
+		}
+
+		if statusRequest.ReplacementCharge > 0 {
+			note := statusRequest.Note
+			if note == "" {
+				note = replacementNote
+			}
+			_, err = uc.fineRepo.AddFineEntry(txCtx, &entity.FineEntry{
+				UserId:    updated.UserId,
+				BorrowId:  &updated.Id,
+				Kind:      entity.FineKindCharge,
+				Amount:    statusRequest.ReplacementCharge,
+				Note:      note,
+				CreatedBy: &staffId,
+			})
+			if err != nil {
+				return err
+			}
+			fineAmount += statusRequest.ReplacementCharge
+		}
+
+		borrowResponse = uc.convertBorrowToRes(updated)
+		borrowResponse.FineAmount = fineAmount
 		return nil
 	})
 
@@ -154,5 +789,5 @@ func (uc borrowUsecaseImpl) Return(ctx context.Context, returnRequest *dto.Retur
 		return nil, err
 	}
 
-	return uc.convertBorrowToReturnRes(borrowed), nil
+	return borrowResponse, nil
 }
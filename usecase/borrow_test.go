@@ -6,6 +6,7 @@ import (
 	"archive_lib/entity"
 	"archive_lib/mocks"
 	"archive_lib/usecase"
+	"archive_lib/util/metrics"
 	"context"
 	"errors"
 	"net/http/httptest"
@@ -13,14 +14,29 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 var (
+	loanConfig = usecase.LoanConfig{
+		LoanDays:       14,
+		MaxRenewals:    2,
+		HoldPickupDays: 3,
+		MaxActiveLoans: map[string]int{
+			entity.RoleMember:    5,
+			entity.RoleLibrarian: 10,
+		},
+		DailyFine:     50,
+		FineThreshold: 0,
+	}
+
 	recordId      = 1
 	bookId        = 1
+	copyId        = 1
 	borrowingDate = time.Now()
+	dueDate       = borrowingDate.AddDate(0, 0, loanConfig.LoanDays)
 
 	borrowRequest = &dto.BorrowRequest{
 		BookId: &bookId,
@@ -30,25 +46,47 @@ var (
 	borrow = &entity.Borrow{
 		UserId: 1,
 		BookId: bookId,
+		CopyId: copyId,
 	}
 
 	borrowed = &entity.Borrow{
 		Id:            recordId,
 		UserId:        1,
 		BookId:        bookId,
+		CopyId:        copyId,
 		Status:        "borrowed",
 		BorrowingDate: borrowingDate,
+		DueDate:       dueDate,
 	}
 
 	borrowResponse = &dto.BorrowResponse{
 		Id:            recordId,
 		UserId:        1,
 		BookId:        bookId,
+		CopyId:        copyId,
 		Status:        "borrowed",
 		BorrowingDate: borrowingDate,
+		DueDate:       dueDate,
 	}
 )
 
+// newMockHoldRepo stubs an empty hold queue.
+func newMockHoldRepo() *mocks.HoldRepo {
+	mockHoldRepo := new(mocks.HoldRepo)
+	mockHoldRepo.On("ExpireReadyHolds", mock.Anything, mock.Anything).Return([]int{}, nil)
+	mockHoldRepo.On("FulfilHold", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+	mockHoldRepo.On("ReadyNextHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	mockHoldRepo.On("HasWaitingHolds", mock.Anything, mock.Anything).Return(false, nil)
+	return mockHoldRepo
+}
+
+// newMockCopyRepo stubs the copy status updates.
+func newMockCopyRepo() *mocks.CopyRepo {
+	mockCopyRepo := new(mocks.CopyRepo)
+	mockCopyRepo.On("SetCopyStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return mockCopyRepo
+}
+
 func TestRecordBorrowUsecase(t *testing.T) {
 	t.Run("should return borrowed book when there is no error", func(t *testing.T) {
 		w := httptest.NewRecorder()
@@ -56,6 +94,8 @@ func TestRecordBorrowUsecase(t *testing.T) {
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockCopyRepo := newMockCopyRepo()
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
@@ -65,10 +105,9 @@ func TestRecordBorrowUsecase(t *testing.T) {
 			}),
 		).Return(nil)
 		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
-		mockBookRepo.On("IsStockAvailable", ctx, bookId).Return(true, nil)
-		mockBorrowRepo.On("Record", ctx, borrow).Return(borrowed, nil)
-		mockBookRepo.On("DecrementStock", ctx, bookId).Return(nil)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(copyId, nil)
+		mockBorrowRepo.On("Record", ctx, borrow, loanConfig.LoanDays).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
 
 		borrowRecord, _ := borrowUsecase.Record(ctx, borrowRequest)
 
@@ -82,6 +121,7 @@ func TestRecordBorrowUsecase(t *testing.T) {
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
@@ -91,7 +131,7 @@ func TestRecordBorrowUsecase(t *testing.T) {
 			}),
 		).Return(errIsBookExisted)
 		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(false, errIsBookExisted)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), new(mocks.CopyRepo), mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
 
 		_, err := borrowUsecase.Record(ctx, borrowRequest)
 
@@ -105,6 +145,7 @@ func TestRecordBorrowUsecase(t *testing.T) {
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
@@ -114,35 +155,37 @@ func TestRecordBorrowUsecase(t *testing.T) {
 			}),
 		).Return(errBookNotFound)
 		mockBookRepo.On("IsBookExisted", ctx, 1).Return(false, nil)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), new(mocks.CopyRepo), mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
 
 		_, err := borrowUsecase.Record(ctx, borrowRequest)
 
 		assert.Equal(t, err, errBookNotFound)
 	})
 
-	t.Run("should return error when stock checking encounters error", func(t *testing.T) {
+	t.Run("should return error when taking an available copy encounters error", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
-		errIsStockAvailable := errors.New("error")
+		errTakeAvailableCopy := errors.New("error")
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockCopyRepo := new(mocks.CopyRepo)
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
 			mock.MatchedBy(func(txFn func(context.Context) error) bool {
 				err := txFn(ctx)
-				return err == errIsStockAvailable
+				return err == errTakeAvailableCopy
 			}),
-		).Return(errIsStockAvailable)
+		).Return(errTakeAvailableCopy)
 		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
-		mockBookRepo.On("IsStockAvailable", ctx, bookId).Return(false, errIsStockAvailable)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(0, errTakeAvailableCopy)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
 
 		_, err := borrowUsecase.Record(ctx, borrowRequest)
 
-		assert.Equal(t, err, errIsStockAvailable)
+		assert.Equal(t, err, errTakeAvailableCopy)
 	})
 
 	t.Run("should return error when borrowing book with empty stock", func(t *testing.T) {
@@ -152,6 +195,8 @@ func TestRecordBorrowUsecase(t *testing.T) {
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockCopyRepo := new(mocks.CopyRepo)
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
@@ -161,12 +206,14 @@ func TestRecordBorrowUsecase(t *testing.T) {
 			}),
 		).Return(errEmptyStock)
 		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
-		mockBookRepo.On("IsStockAvailable", ctx, bookId).Return(false, nil)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(0, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+		rejected := testutil.ToFloat64(metrics.BorrowsRejected.WithLabelValues(metrics.ReasonEmptyStock))
 
 		_, err := borrowUsecase.Record(ctx, borrowRequest)
 
 		assert.Equal(t, err, errEmptyStock)
+		assert.Equal(t, rejected+1, testutil.ToFloat64(metrics.BorrowsRejected.WithLabelValues(metrics.ReasonEmptyStock)))
 	})
 
 	t.Run("should return error when record borrow encounters error", func(t *testing.T) {
@@ -176,6 +223,8 @@ func TestRecordBorrowUsecase(t *testing.T) {
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockCopyRepo := new(mocks.CopyRepo)
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
@@ -185,38 +234,1015 @@ func TestRecordBorrowUsecase(t *testing.T) {
 			}),
 		).Return(errRecord)
 		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
-		mockBookRepo.On("IsStockAvailable", ctx, bookId).Return(true, nil)
-		mockBorrowRepo.On("Record", ctx, borrow).Return(nil, errRecord)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(copyId, nil)
+		mockBorrowRepo.On("Record", ctx, borrow, loanConfig.LoanDays).Return(nil, errRecord)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
 
 		_, err := borrowUsecase.Record(ctx, borrowRequest)
 
 		assert.Equal(t, err, errRecord)
 	})
 
-	t.Run("should return error when decrement stock of the borrowed book encounters error", func(t *testing.T) {
+	t.Run("should return error when marking the copy as borrowed encounters error", func(t *testing.T) {
 		w := httptest.NewRecorder()
 		ctx, _ := gin.CreateTestContext(w)
-		errDecrementStock := errors.New("error")
+		errSetCopyStatus := errors.New("error")
 		mockBookRepo := new(mocks.BookRepo)
 		mockBorrowRepo := new(mocks.BorrowRepo)
 		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockCopyRepo := new(mocks.CopyRepo)
 		mockTxRepo.On(
 			"WithinTransaction",
 			ctx,
 			mock.MatchedBy(func(txFn func(context.Context) error) bool {
 				err := txFn(ctx)
-				return err == errDecrementStock
+				return err == errSetCopyStatus
 			}),
-		).Return(errDecrementStock)
+		).Return(errSetCopyStatus)
 		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
-		mockBookRepo.On("IsStockAvailable", ctx, bookId).Return(true, nil)
-		mockBorrowRepo.On("Record", ctx, borrow).Return(borrowed, nil)
-		mockBookRepo.On("DecrementStock", ctx, bookId).Return(errDecrementStock)
-		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockTxRepo)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(copyId, nil)
+		mockBorrowRepo.On("Record", ctx, borrow, loanConfig.LoanDays).Return(borrowed, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, copyId, entity.CopyStatusBorrowed).Return(errSetCopyStatus)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
 
 		_, err := borrowUsecase.Record(ctx, borrowRequest)
 
-		assert.Equal(t, err, errDecrementStock)
+		assert.Equal(t, err, errSetCopyStatus)
+	})
+}
+
+func TestCheckoutUsecase(t *testing.T) {
+	t.Run("should record the loan for the member with the librarian who checked it out", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		staffId := 2
+		memberId := 3
+		mockBookRepo := new(mocks.BookRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockUserRepo.On("IsUserExisted", ctx, memberId).Return(true, nil)
+		mockUserRepo.On("GetUserById", ctx, memberId).Return(&entity.User{Id: memberId, Role: "member"}, nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(copyId, nil)
+		mockBorrowRepo.On("Record", ctx, &entity.Borrow{UserId: memberId, BookId: bookId, CopyId: copyId, CheckedOutBy: &staffId}, loanConfig.LoanDays).
+			Return(&entity.Borrow{Id: recordId, UserId: memberId, BookId: bookId, CopyId: copyId, Status: "borrowed", CheckedOutBy: &staffId}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockUserRepo, mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowRecord, err := borrowUsecase.Checkout(ctx, &dto.BorrowRequest{BookId: &bookId, UserId: memberId}, staffId)
+
+		assert.Nil(t, err)
+		assert.Equal(t, memberId, borrowRecord.UserId)
+		assert.Equal(t, &staffId, borrowRecord.CheckedOutBy)
+	})
+
+	t.Run("should apply the borrowing policies to the member", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockUserRepo.On("IsUserExisted", ctx, 3).Return(true, nil)
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&entity.User{Id: 3, Role: "member"}, nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockBorrowRepo.On("HasOverdueBorrows", ctx, 3).Return(true, nil)
+		policies := usecase.BorrowPolicies{usecase.NewOverdueLoanPolicy(mockBorrowRepo)}
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, mockUserRepo, new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, policies, loanConfig)
+
+		borrowRecord, err := borrowUsecase.Checkout(ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 3}, 2)
+
+		assert.Nil(t, borrowRecord)
+		assert.ErrorIs(t, err, apperror.ErrHasOverdueLoans{})
+		mockBorrowRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrUserNotFound when the member does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockUserRepo := new(mocks.UserRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockUserRepo.On("IsUserExisted", ctx, 3).Return(false, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), mockUserRepo, new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowRecord, err := borrowUsecase.Checkout(ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 3}, 2)
+
+		assert.Nil(t, borrowRecord)
+		assert.ErrorIs(t, err, apperror.ErrUserNotFound{})
+		mockBorrowRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrBorrowerNotMember when the borrower is a librarian", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockUserRepo := new(mocks.UserRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockUserRepo.On("IsUserExisted", ctx, 4).Return(true, nil)
+		mockUserRepo.On("GetUserById", ctx, 4).Return(&entity.User{Id: 4, Role: "librarian"}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), mockUserRepo, new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowRecord, err := borrowUsecase.Checkout(ctx, &dto.BorrowRequest{BookId: &bookId, UserId: 4}, 2)
+
+		assert.Nil(t, borrowRecord)
+		assert.ErrorIs(t, err, apperror.ErrBorrowerNotMember{})
+		mockBorrowRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestListBorrowsUsecase(t *testing.T) {
+	t.Run("should return overdue records with days overdue when filtering by overdue status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		overdueDate := time.Now().AddDate(0, 0, -3)
+		query := &entity.BorrowQuery{Status: "overdue", Limit: usecase.DefaultPageSize}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("CountBorrows", ctx, query).Return(1, nil)
+		mockBorrowRepo.On("ListBorrows", ctx, query).Return([]entity.Borrow{
+			{Id: recordId, UserId: 1, BookId: bookId, Status: "overdue", BorrowingDate: borrowingDate, DueDate: overdueDate, DaysOverdue: 3},
+		}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+		expectedPage := &dto.BorrowPageResponse{
+			Data: []dto.BorrowResponse{
+				{Id: recordId, UserId: 1, BookId: bookId, Status: "overdue", BorrowingDate: borrowingDate, DueDate: overdueDate, DaysOverdue: 3},
+			},
+			Pagination: dto.PaginationResponse{Total: 1, Page: 1, PerPage: usecase.DefaultPageSize},
+		}
+
+		borrowPage, err := borrowUsecase.ListBorrows(ctx, &dto.BorrowListQuery{Status: "overdue"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedPage, borrowPage)
+	})
+
+	t.Run("should filter by user, book and borrowing date on the requested page", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		userId, page, perPage := 2, 3, 5
+		after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		query := &entity.BorrowQuery{UserId: &userId, BookId: &bookId, BorrowedAfter: &after, BorrowedBefore: &before, Limit: 5, Offset: 10}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("CountBorrows", ctx, query).Return(12, nil)
+		mockBorrowRepo.On("ListBorrows", ctx, query).Return([]entity.Borrow{}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		borrowPage, err := borrowUsecase.ListBorrows(ctx, &dto.BorrowListQuery{
+			UserId:         &userId,
+			BookId:         &bookId,
+			BorrowedAfter:  &after,
+			BorrowedBefore: &before,
+			Page:           &page,
+			PerPage:        &perPage,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, dto.PaginationResponse{Total: 12, Page: 3, PerPage: 5}, borrowPage.Pagination)
+	})
+
+	t.Run("should return ErrInvalidBorrowDateRange when borrowed_before is not after borrowed_after", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		after := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+		before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.ListBorrows(ctx, &dto.BorrowListQuery{BorrowedAfter: &after, BorrowedBefore: &before})
+
+		assert.Equal(t, apperror.ErrInvalidBorrowDateRange{}, err)
+		mockBorrowRepo.AssertNotCalled(t, "ListBorrows", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return error when listing records encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		query := &entity.BorrowQuery{Limit: usecase.DefaultPageSize}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("CountBorrows", ctx, query).Return(0, nil)
+		mockBorrowRepo.On("ListBorrows", ctx, query).Return(nil, errors.New("error"))
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.ListBorrows(ctx, &dto.BorrowListQuery{})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestListUserBorrowsUsecase(t *testing.T) {
+	t.Run("should scope the records to the user even when another user is requested", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		userId, otherUserId := 1, 2
+		query := &entity.BorrowQuery{UserId: &userId, Limit: usecase.DefaultPageSize}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("CountBorrows", ctx, query).Return(1, nil)
+		mockBorrowRepo.On("ListBorrows", ctx, query).Return([]entity.Borrow{*borrowed}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		borrowPage, err := borrowUsecase.ListUserBorrows(ctx, userId, &dto.BorrowListQuery{UserId: &otherUserId})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []dto.BorrowResponse{*borrowResponse}, borrowPage.Data)
+	})
+}
+
+func TestGetBorrowUsecase(t *testing.T) {
+	t.Run("should return the record to the member who borrowed it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		response, err := borrowUsecase.GetBorrow(ctx, recordId, 1, "member")
+
+		assert.Nil(t, err)
+		assert.Equal(t, borrowResponse, response)
+	})
+
+	t.Run("should return the record of any user to a librarian", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		response, err := borrowUsecase.GetBorrow(ctx, recordId, 9, "librarian")
+
+		assert.Nil(t, err)
+		assert.Equal(t, borrowResponse, response)
+	})
+
+	t.Run("should return ErrBorrowNotFound when a member looks up another user's record", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.GetBorrow(ctx, recordId, 9, "member")
+
+		assert.Equal(t, apperror.ErrBorrowNotFound{}, err)
+	})
+
+	t.Run("should return ErrBorrowNotFound when the record does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(false, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), new(mocks.TransactionRepo), usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.GetBorrow(ctx, recordId, 1, "member")
+
+		assert.Equal(t, apperror.ErrBorrowNotFound{}, err)
+	})
+}
+
+func TestRenewBorrowUsecase(t *testing.T) {
+	renewedDueDate := dueDate.AddDate(0, 0, loanConfig.LoanDays)
+
+	t.Run("should extend the due date and keep the renewal history when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		mockBorrowRepo.On("Renew", ctx, &entity.Borrow{Id: recordId, UserId: 1}, loanConfig.LoanDays).Return(&entity.Borrow{
+			Id:            recordId,
+			UserId:        1,
+			BookId:        bookId,
+			Status:        "borrowed",
+			BorrowingDate: borrowingDate,
+			DueDate:       renewedDueDate,
+			RenewalCount:  1,
+		}, nil)
+		mockBorrowRepo.On("AddRenewal", ctx, &entity.Renewal{BorrowId: recordId, PreviousDueDate: dueDate, NewDueDate: renewedDueDate}).Return(nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+		expectedResponse := &dto.BorrowResponse{
+			Id:            recordId,
+			UserId:        1,
+			BookId:        bookId,
+			Status:        "borrowed",
+			BorrowingDate: borrowingDate,
+			DueDate:       renewedDueDate,
+			RenewalCount:  1,
+		}
+
+		borrowResponse, err := borrowUsecase.Renew(ctx, recordId, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponse, borrowResponse)
+	})
+
+	t.Run("should return ErrRenewalLimitReached when the loan was renewed too many times", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errRenewalLimitReached := apperror.ErrRenewalLimitReached{}
+		renewedBorrow := *borrowed
+		renewedBorrow.RenewalCount = loanConfig.MaxRenewals
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errRenewalLimitReached
+			}),
+		).Return(errRenewalLimitReached)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(&renewedBorrow, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Renew(ctx, recordId, 1)
+
+		assert.Equal(t, errRenewalLimitReached, err)
+	})
+
+	t.Run("should return ErrLoanNotRenewable when renewing a loan claimed returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errLoanNotRenewable := apperror.ErrLoanNotRenewable{}
+		claimedBorrow := *borrowed
+		claimedBorrow.Status = entity.BorrowStatusClaimedReturned
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errLoanNotRenewable
+			}),
+		).Return(errLoanNotRenewable)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(&claimedBorrow, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Renew(ctx, recordId, 1)
+
+		assert.Equal(t, errLoanNotRenewable, err)
+		mockBorrowRepo.AssertNotCalled(t, "Renew", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrBorrowNotFound when renewing the record of another member", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errBorrowNotFound := apperror.ErrBorrowNotFound{}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errBorrowNotFound
+			}),
+		).Return(errBorrowNotFound)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 2).Return(false, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Renew(ctx, recordId, 2)
+
+		assert.Equal(t, errBorrowNotFound, err)
+		mockBorrowRepo.AssertNotCalled(t, "Renew", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrAlreadyReturned when renewing a returned loan", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errAlreadyReturned := apperror.ErrAlreadyReturned{}
+		returnedBorrow := *borrowed
+		returnedBorrow.ReturningDate = time.Now()
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo := newMockHoldRepo()
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errAlreadyReturned
+			}),
+		).Return(errAlreadyReturned)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(&returnedBorrow, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Renew(ctx, recordId, 1)
+
+		assert.Equal(t, errAlreadyReturned, err)
+	})
+}
+
+func TestBorrowWithHoldsUsecase(t *testing.T) {
+	t.Run("should borrow the copy reserved for the user's ready hold without taking another copy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, bookId).Return([]int{}, nil)
+		mockHoldRepo.On("FulfilHold", ctx, bookId, 1).Return(copyId, nil)
+		mockBorrowRepo.On("Record", ctx, borrow, loanConfig.LoanDays).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowRecord, err := borrowUsecase.Record(ctx, borrowRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, borrowResponse, borrowRecord)
+		mockCopyRepo.AssertNotCalled(t, "TakeAvailableCopy", ctx, bookId)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, copyId, entity.CopyStatusBorrowed)
+	})
+
+	t.Run("should reserve the returned copy for the next hold instead of the shelf", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		returnId := recordId
+		returnRequest := &dto.ReturnRequest{Id: &returnId, UserId: 1}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsReturned", ctx, recordId).Return(false, nil)
+		mockBorrowRepo.On("Return", ctx, &entity.Borrow{Id: recordId, UserId: 1}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: "returned"}, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, bookId, copyId, loanConfig.HoldPickupDays).Return(true, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, copyId, entity.CopyStatusReserved).Return(nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Return(ctx, returnRequest)
+
+		assert.Nil(t, err)
+		mockCopyRepo.AssertNotCalled(t, "SetCopyStatus", ctx, copyId, entity.CopyStatusAvailable)
+	})
+
+	t.Run("should return ErrBookHasHolds when renewing a book other members are waiting for", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errBookHasHolds := apperror.ErrBookHasHolds{}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errBookHasHolds
+			}),
+		).Return(errBookHasHolds)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		mockHoldRepo.On("HasWaitingHolds", ctx, bookId).Return(true, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Renew(ctx, recordId, 1)
+
+		assert.Equal(t, errBookHasHolds, err)
+	})
+}
+
+func TestReturnBorrowFineUsecase(t *testing.T) {
+	t.Run("should charge the daily fine for each day the book is returned late", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		returnId := recordId
+		returnRequest := &dto.ReturnRequest{Id: &returnId, UserId: 1}
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockFineRepo := new(mocks.FineRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsReturned", ctx, recordId).Return(false, nil)
+		mockBorrowRepo.On("Return", ctx, &entity.Borrow{Id: recordId, UserId: 1}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, Status: "returned", DaysOverdue: 3}, nil)
+		mockFineRepo.On("AddFineEntry", ctx, &entity.FineEntry{
+			UserId:   1,
+			BorrowId: &returnId,
+			Kind:     entity.FineKindCharge,
+			Amount:   3 * loanConfig.DailyFine,
+			Note:     "Returned 3 day(s) late",
+		}).Return(&entity.FineEntry{Id: 1}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), newMockCopyRepo(), newMockHoldRepo(), mockFineRepo, mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		returnResponse, err := borrowUsecase.Return(ctx, returnRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3*loanConfig.DailyFine, returnResponse.FineAmount)
+	})
+
+	t.Run("should charge a whole day when the book is returned less than a day late", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		returnId := recordId
+		returnRequest := &dto.ReturnRequest{Id: &returnId, UserId: 1}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockFineRepo := new(mocks.FineRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsReturned", ctx, recordId).Return(false, nil)
+		// 23 hours past the due date is reported as one day overdue
+		mockBorrowRepo.On("Return", ctx, &entity.Borrow{Id: recordId, UserId: 1}).Return(&entity.Borrow{
+			Id:            recordId,
+			UserId:        1,
+			BookId:        bookId,
+			Status:        "returned",
+			DueDate:       dueDate,
+			ReturningDate: dueDate.Add(23 * time.Hour),
+			DaysOverdue:   1,
+		}, nil)
+		mockFineRepo.On("AddFineEntry", ctx, &entity.FineEntry{
+			UserId:   1,
+			BorrowId: &returnId,
+			Kind:     entity.FineKindCharge,
+			Amount:   loanConfig.DailyFine,
+			Note:     "Returned 1 day(s) late",
+		}).Return(&entity.FineEntry{Id: 1}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), newMockCopyRepo(), newMockHoldRepo(), mockFineRepo, mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		returnResponse, err := borrowUsecase.Return(ctx, returnRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, returnResponse.DaysOverdue)
+		assert.Equal(t, loanConfig.DailyFine, returnResponse.FineAmount)
+	})
+
+	t.Run("should not charge a fine when the book is returned on time", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		returnId := recordId
+		returnRequest := &dto.ReturnRequest{Id: &returnId, UserId: 1}
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockFineRepo := new(mocks.FineRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsReturned", ctx, recordId).Return(false, nil)
+		mockBorrowRepo.On("Return", ctx, &entity.Borrow{Id: recordId, UserId: 1}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, Status: "returned"}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), newMockCopyRepo(), newMockHoldRepo(), mockFineRepo, mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		returnResponse, err := borrowUsecase.Return(ctx, returnRequest)
+
+		assert.Nil(t, err)
+		assert.Zero(t, returnResponse.FineAmount)
+		mockFineRepo.AssertNotCalled(t, "AddFineEntry", mock.Anything, mock.Anything)
+	})
+}
+
+// runTx lets the mocked transaction and savepoints run the function they are given.
+func runTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestBatchRecordUsecase(t *testing.T) {
+	secondBookId := 2
+
+	newBatchMocks := func(ctx context.Context) (*mocks.BorrowRepo, *mocks.BookRepo, *mocks.CopyRepo, *mocks.TransactionRepo) {
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockTxRepo.On("WithinSavepoint", ctx, mock.Anything).Return(runTx)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockBookRepo.On("IsBookExisted", ctx, secondBookId).Return(true, nil)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, bookId).Return(copyId, nil)
+		mockCopyRepo.On("TakeAvailableCopy", ctx, secondBookId).Return(0, nil)
+		mockBorrowRepo.On("Record", ctx, &entity.Borrow{UserId: 1, BookId: bookId, CopyId: copyId}, loanConfig.LoanDays).Return(borrowed, nil)
+		return mockBorrowRepo, mockBookRepo, mockCopyRepo, mockTxRepo
+	}
+
+	t.Run("should keep the borrowed books and report the failed ones in best-effort mode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo, mockBookRepo, mockCopyRepo, mockTxRepo := newBatchMocks(ctx)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+		expectedResponse := &dto.BatchResponse{
+			Mode:      entity.BatchModeBestEffort,
+			Committed: true,
+			Succeeded: 1,
+			Failed:    1,
+			Items: []dto.BatchItemResponse{
+				{BookId: bookId, Status: entity.BatchItemSucceeded, Data: borrowResponse},
+				{BookId: secondBookId, Status: entity.BatchItemFailed, Error: apperror.ErrEmptyStock{}.Error()},
+			},
+		}
+
+		batchResponse, err := borrowUsecase.BatchRecord(ctx, &dto.BatchBorrowRequest{
+			BookIds: []int{bookId, secondBookId},
+			Mode:    entity.BatchModeBestEffort,
+			UserId:  1,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponse, batchResponse)
+	})
+
+	t.Run("should roll every book back and skip the rest when an item fails in atomic mode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo, mockBookRepo, mockCopyRepo, mockTxRepo := newBatchMocks(ctx)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+		expectedResponse := &dto.BatchResponse{
+			Mode:   entity.BatchModeAtomic,
+			Failed: 1,
+			Items: []dto.BatchItemResponse{
+				{BookId: bookId, Status: entity.BatchItemRolledBack},
+				{BookId: secondBookId, Status: entity.BatchItemFailed, Error: apperror.ErrEmptyStock{}.Error()},
+				{BookId: bookId, Status: entity.BatchItemSkipped},
+			},
+		}
+
+		batchResponse, err := borrowUsecase.BatchRecord(ctx, &dto.BatchBorrowRequest{
+			BookIds: []int{bookId, secondBookId, bookId},
+			UserId:  1,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponse, batchResponse)
+	})
+
+	t.Run("should return error when an item encounters an unexpected error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockTxRepo.On("WithinSavepoint", ctx, mock.Anything).Return(runTx)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(false, errors.New("error"))
+		borrowUsecase := usecase.NewBorrowUsecase(new(mocks.BorrowRepo), mockBookRepo, new(mocks.UserRepo), newMockCopyRepo(), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.BatchRecord(ctx, &dto.BatchBorrowRequest{
+			BookIds: []int{bookId},
+			Mode:    entity.BatchModeBestEffort,
+			UserId:  1,
+		})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestBatchReturnUsecase(t *testing.T) {
+	t.Run("should report the records that cannot be returned in best-effort mode", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		otherRecordId := 2
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockTxRepo.On("WithinSavepoint", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, otherRecordId, 1).Return(false, nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsReturned", ctx, recordId).Return(false, nil)
+		mockBorrowRepo.On("Return", ctx, &entity.Borrow{Id: recordId, UserId: 1}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, Status: "returned"}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), newMockCopyRepo(), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		batchResponse, err := borrowUsecase.BatchReturn(ctx, &dto.BatchReturnRequest{
+			Ids:    []int{recordId, otherRecordId},
+			Mode:   entity.BatchModeBestEffort,
+			UserId: 1,
+		})
+
+		assert.Nil(t, err)
+		assert.True(t, batchResponse.Committed)
+		assert.Equal(t, 1, batchResponse.Succeeded)
+		assert.Equal(t, entity.BatchItemSucceeded, batchResponse.Items[0].Status)
+		assert.Equal(t, apperror.ErrReturnUnauthorized{}.Error(), batchResponse.Items[1].Error)
+	})
+}
+
+func TestBorrowByBarcodeUsecase(t *testing.T) {
+	barcode := "BK000001-002"
+	scannedCopyId := 2
+
+	t.Run("should borrow the scanned copy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, barcode).Return(true, nil)
+		mockCopyRepo.On("GetCopyByBarcode", ctx, barcode).Return(&entity.BookCopy{Id: scannedCopyId, BookId: bookId, Barcode: barcode, Status: entity.CopyStatusAvailable}, nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockBorrowRepo.On("Record", ctx, &entity.Borrow{UserId: 1, BookId: bookId, CopyId: scannedCopyId}, loanConfig.LoanDays).Return(borrowed, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, scannedCopyId, entity.CopyStatusBorrowed).Return(nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Record(ctx, &dto.BorrowRequest{Barcode: barcode, UserId: 1})
+
+		assert.Nil(t, err)
+		mockCopyRepo.AssertNotCalled(t, "TakeAvailableCopy", ctx, bookId)
+	})
+
+	t.Run("should return ErrCopyUnavailable when the scanned copy is reserved for another member", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, barcode).Return(true, nil)
+		mockCopyRepo.On("GetCopyByBarcode", ctx, barcode).Return(&entity.BookCopy{Id: scannedCopyId, BookId: bookId, Barcode: barcode, Status: entity.CopyStatusReserved}, nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(new(mocks.BorrowRepo), mockBookRepo, new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Record(ctx, &dto.BorrowRequest{Barcode: barcode, UserId: 1})
+
+		assert.Equal(t, apperror.ErrCopyUnavailable{}, err)
+	})
+
+	t.Run("should pass the copy reserved for the user on when another copy is scanned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, barcode).Return(true, nil)
+		mockCopyRepo.On("GetCopyByBarcode", ctx, barcode).Return(&entity.BookCopy{Id: scannedCopyId, BookId: bookId, Barcode: barcode, Status: entity.CopyStatusAvailable}, nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, bookId).Return([]int{}, nil)
+		mockHoldRepo.On("FulfilHold", ctx, bookId, 1).Return(copyId, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, bookId, copyId, loanConfig.HoldPickupDays).Return(false, nil)
+		mockBorrowRepo.On("Record", ctx, &entity.Borrow{UserId: 1, BookId: bookId, CopyId: scannedCopyId}, loanConfig.LoanDays).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Record(ctx, &dto.BorrowRequest{Barcode: barcode, UserId: 1})
+
+		assert.Nil(t, err)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, copyId, entity.CopyStatusAvailable)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, scannedCopyId, entity.CopyStatusBorrowed)
+	})
+
+	t.Run("should return ErrCopyNotFound when the barcode is unknown", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, barcode).Return(false, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(new(mocks.BorrowRepo), new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Record(ctx, &dto.BorrowRequest{Barcode: barcode, UserId: 1})
+
+		assert.Equal(t, apperror.ErrCopyNotFound{}, err)
+	})
+}
+
+func TestReturnByBarcodeUsecase(t *testing.T) {
+	barcode := "BK000001-001"
+
+	t.Run("should return the active loan of the scanned copy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, barcode).Return(true, nil)
+		mockCopyRepo.On("GetCopyByBarcode", ctx, barcode).Return(&entity.BookCopy{Id: copyId, BookId: bookId, Barcode: barcode, Status: entity.CopyStatusBorrowed}, nil)
+		mockBorrowRepo.On("GetActiveBorrowIdByCopy", ctx, copyId).Return(recordId, nil)
+		mockBorrowRepo.On("IsUserAuthorized", ctx, recordId, 1).Return(true, nil)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("IsReturned", ctx, recordId).Return(false, nil)
+		mockBorrowRepo.On("Return", ctx, &entity.Borrow{Id: recordId, UserId: 1}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: "returned"}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		returnResponse, err := borrowUsecase.Return(ctx, &dto.ReturnRequest{Barcode: barcode, UserId: 1})
+
+		assert.Nil(t, err)
+		assert.Equal(t, copyId, returnResponse.CopyId)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, copyId, entity.CopyStatusAvailable)
+	})
+
+	t.Run("should return ErrBorrowNotFound when the scanned copy is not on loan", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, barcode).Return(true, nil)
+		mockCopyRepo.On("GetCopyByBarcode", ctx, barcode).Return(&entity.BookCopy{Id: copyId, BookId: bookId, Barcode: barcode, Status: entity.CopyStatusAvailable}, nil)
+		mockBorrowRepo.On("GetActiveBorrowIdByCopy", ctx, copyId).Return(0, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		_, err := borrowUsecase.Return(ctx, &dto.ReturnRequest{Barcode: barcode, UserId: 1})
+
+		assert.Equal(t, apperror.ErrBorrowNotFound{}, err)
+	})
+}
+
+func TestUpdateBorrowStatusUsecase(t *testing.T) {
+	t.Run("should mark the copy lost and charge the replacement when a borrowed record is lost", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		staffId := 2
+		lostId := recordId
+		statusRequest := &dto.BorrowStatusRequest{Status: entity.BorrowStatusLost, ReplacementCharge: 25000}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockFineRepo := new(mocks.FineRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		mockBorrowRepo.On("UpdateStatus", ctx, &entity.Borrow{Id: recordId, Status: entity.BorrowStatusLost}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: entity.BorrowStatusLost}, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, copyId, entity.CopyStatusLost).Return(nil)
+		mockFineRepo.On("AddFineEntry", ctx, &entity.FineEntry{
+			UserId:    1,
+			BorrowId:  &lostId,
+			Kind:      entity.FineKindCharge,
+			Amount:    25000,
+			Note:      "Replacement for lost copy",
+			CreatedBy: &staffId,
+		}).Return(&entity.FineEntry{Id: 1}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), mockFineRepo, mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowResponse, err := borrowUsecase.UpdateStatus(ctx, recordId, staffId, statusRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.BorrowStatusLost, borrowResponse.Status)
+		assert.Equal(t, int64(25000), borrowResponse.FineAmount)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, copyId, entity.CopyStatusLost)
+	})
+
+	t.Run("should put the copy back into circulation when a lost record is returned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		statusRequest := &dto.BorrowStatusRequest{Status: entity.BorrowStatusReturned}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockFineRepo := new(mocks.FineRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: entity.BorrowStatusLost}, nil)
+		mockBorrowRepo.On("UpdateStatus", ctx, &entity.Borrow{Id: recordId, Status: entity.BorrowStatusReturned}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: entity.BorrowStatusReturned, DaysOverdue: 3}, nil)
+		mockCopyRepo.On("GetCopyById", ctx, copyId).Return(&entity.BookCopy{Id: copyId, BookId: bookId, Status: entity.CopyStatusLost}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, newMockHoldRepo(), mockFineRepo, mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowResponse, err := borrowUsecase.UpdateStatus(ctx, recordId, 2, statusRequest)
+
+		assert.Nil(t, err)
+		assert.Zero(t, borrowResponse.FineAmount)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, copyId, entity.CopyStatusAvailable)
+		mockFineRepo.AssertNotCalled(t, "AddFineEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should leave the copy alone when a lost record is returned after the copy was lent again", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		statusRequest := &dto.BorrowStatusRequest{Status: entity.BorrowStatusReturned}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockCopyRepo := newMockCopyRepo()
+		mockHoldRepo := newMockHoldRepo()
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: entity.BorrowStatusLost}, nil)
+		mockBorrowRepo.On("UpdateStatus", ctx, &entity.Borrow{Id: recordId, Status: entity.BorrowStatusReturned}).Return(&entity.Borrow{Id: recordId, UserId: 1, BookId: bookId, CopyId: copyId, Status: entity.BorrowStatusReturned}, nil)
+		mockCopyRepo.On("GetCopyById", ctx, copyId).Return(&entity.BookCopy{Id: copyId, BookId: bookId, Status: entity.CopyStatusBorrowed}, nil)
+		mockBorrowRepo.On("GetActiveBorrowIdByCopy", ctx, copyId).Return(recordId+1, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), mockCopyRepo, mockHoldRepo, new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowResponse, err := borrowUsecase.UpdateStatus(ctx, recordId, 2, statusRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.BorrowStatusReturned, borrowResponse.Status)
+		mockCopyRepo.AssertNotCalled(t, "SetCopyStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockHoldRepo.AssertNotCalled(t, "ReadyNextHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrInvalidBorrowTransition when a returned record is reported lost", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		statusRequest := &dto.BorrowStatusRequest{Status: entity.BorrowStatusLost}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(&entity.Borrow{Id: recordId, Status: entity.BorrowStatusReturned}, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowResponse, err := borrowUsecase.UpdateStatus(ctx, recordId, 2, statusRequest)
+
+		assert.Nil(t, borrowResponse)
+		assert.ErrorIs(t, err, apperror.ErrInvalidBorrowTransition{})
+		mockBorrowRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrReplacementChargeNotAllowed when charging a claimed return", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		statusRequest := &dto.BorrowStatusRequest{Status: entity.BorrowStatusClaimedReturned, ReplacementCharge: 25000}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(true, nil)
+		mockBorrowRepo.On("GetBorrowById", ctx, recordId).Return(borrowed, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowResponse, err := borrowUsecase.UpdateStatus(ctx, recordId, 2, statusRequest)
+
+		assert.Nil(t, borrowResponse)
+		assert.ErrorIs(t, err, apperror.ErrReplacementChargeNotAllowed{})
+	})
+
+	t.Run("should return ErrBorrowNotFound when the record does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		statusRequest := &dto.BorrowStatusRequest{Status: entity.BorrowStatusLost}
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On("WithinTransaction", ctx, mock.Anything).Return(runTx)
+		mockBorrowRepo.On("IsBorrowExisted", ctx, recordId).Return(false, nil)
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, new(mocks.BookRepo), new(mocks.UserRepo), new(mocks.CopyRepo), newMockHoldRepo(), new(mocks.FineRepo), mockTxRepo, usecase.BorrowPolicies{}, loanConfig)
+
+		borrowResponse, err := borrowUsecase.UpdateStatus(ctx, recordId, 2, statusRequest)
+
+		assert.Nil(t, borrowResponse)
+		assert.ErrorIs(t, err, apperror.ErrBorrowNotFound{})
 	})
 }
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/repo"
+	"archive_lib/util/tracing"
+	"context"
+)
+
+type CopyUsecase interface {
+	ListBookCopies(ctx context.Context, bookId int) ([]dto.CopyResponse, error)
+	AddCopy(ctx context.Context, bookId int, copyRequest *dto.CopyRequest) (*dto.CopyResponse, error)
+	PatchCopy(ctx context.Context, id int, copyPatchRequest *dto.CopyPatchRequest) (*dto.CopyResponse, error)
+}
+
+type copyUsecaseImpl struct {
+	copyRepo   repo.CopyRepo
+	bookRepo   repo.BookRepo
+	borrowRepo repo.BorrowRepo
+	holdRepo   repo.HoldRepo
+	txRepo     repo.TransactionRepo
+	loanConfig LoanConfig
+}
+
+func NewCopyUsecase(copyRepo repo.CopyRepo, bookRepo repo.BookRepo, borrowRepo repo.BorrowRepo, holdRepo repo.HoldRepo, txRepo repo.TransactionRepo, loanConfig LoanConfig) copyUsecaseImpl {
+	return copyUsecaseImpl{
+		copyRepo:   copyRepo,
+		bookRepo:   bookRepo,
+		borrowRepo: borrowRepo,
+		holdRepo:   holdRepo,
+		txRepo:     txRepo,
+		loanConfig: loanConfig,
+	}
+}
+
+func convertCopyToRes(bookCopy *entity.BookCopy) *dto.CopyResponse {
+	return &dto.CopyResponse{
+		Id:        bookCopy.Id,
+		BookId:    bookCopy.BookId,
+		Barcode:   bookCopy.Barcode,
+		Condition: bookCopy.Condition,
+		Location:  bookCopy.Location,
+		Status:    bookCopy.Status,
+	}
+}
+
+func (uc copyUsecaseImpl) ListBookCopies(ctx context.Context, bookId int) ([]dto.CopyResponse, error) {
+	ctx, span := tracing.Start(ctx, "CopyUsecase.ListBookCopies")
+	defer span.End()
+
+	found, err := uc.bookRepo.IsBookExisted(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	copies, err := uc.copyRepo.ListCopiesByBook(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+
+	copyResponses := []dto.CopyResponse{}
+	for i := range copies {
+		copyResponses = append(copyResponses, *convertCopyToRes(&copies[i]))
+	}
+
+	return copyResponses, nil
+}
+
+func (uc copyUsecaseImpl) AddCopy(ctx context.Context, bookId int, copyRequest *dto.CopyRequest) (*dto.CopyResponse, error) {
+	ctx, span := tracing.Start(ctx, "CopyUsecase.AddCopy")
+	defer span.End()
+
+	var bookCopy *entity.BookCopy
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.bookRepo.IsBookExisted(txCtx, bookId)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrBookNotFound{}
+		}
+
+		duplicate, err := uc.copyRepo.IsBarcodeExisted(txCtx, copyRequest.Barcode)
+		if err != nil {
+			return err
+		}
+		if duplicate {
+			return apperror.ErrDuplicateBarcode{}
+		}
+
+		condition := copyRequest.Condition
+		if condition == "" {
+			condition = entity.CopyConditionGood
+		}
+		bookCopy, err = uc.copyRepo.AddCopy(txCtx, &entity.BookCopy{
+			BookId:    bookId,
+			Barcode:   copyRequest.Barcode,
+			Condition: condition,
+			Location:  copyRequest.Location,
+		})
+		if err != nil {
+			return err
+		}
+
+		// a new copy goes to the first member waiting for the book, if any
+		err = allocateCopy(txCtx, uc.holdRepo, uc.copyRepo, bookId, bookCopy.Id, uc.loanConfig.HoldPickupDays)
+		if err != nil {
+			return err
+		}
+
+		bookCopy, err = uc.copyRepo.GetCopyById(txCtx, bookCopy.Id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return convertCopyToRes(bookCopy), nil
+}
+
+func (uc copyUsecaseImpl) PatchCopy(ctx context.Context, id int, copyPatchRequest *dto.CopyPatchRequest) (*dto.CopyResponse, error) {
+	ctx, span := tracing.Start(ctx, "CopyUsecase.PatchCopy")
+	defer span.End()
+
+	var bookCopy *entity.BookCopy
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.copyRepo.IsCopyExisted(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrCopyNotFound{}
+		}
+
+		bookCopy, err = uc.copyRepo.GetCopyById(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if copyPatchRequest.Condition != nil {
+			bookCopy.Condition = *copyPatchRequest.Condition
+		}
+		if copyPatchRequest.Location != nil {
+			bookCopy.Location = *copyPatchRequest.Location
+		}
+
+		bookCopy, err = uc.copyRepo.UpdateCopy(txCtx, bookCopy)
+		if err != nil {
+			return err
+		}
+
+		if copyPatchRequest.Status == nil || *copyPatchRequest.Status == bookCopy.Status {
+			return nil
+		}
+
+		err = uc.changeCopyStatus(txCtx, bookCopy, *copyPatchRequest.Status)
+		if err != nil {
+			return err
+		}
+
+		bookCopy, err = uc.copyRepo.GetCopyById(txCtx, id)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return convertCopyToRes(bookCopy), nil
+}
+
+// changeCopyStatus withdraws a copy from circulation or puts it back on the shelf. Copies out on loan or
+// set aside for a hold have to come back first, and so does a copy still on a lost or claimed returned loan before
+// it goes back on the shelf. A copy back on the shelf goes to the first waiting hold, if any.
+func (uc copyUsecaseImpl) changeCopyStatus(ctx context.Context, bookCopy *entity.BookCopy, status string) error {
+	if bookCopy.Status == entity.CopyStatusBorrowed || bookCopy.Status == entity.CopyStatusReserved {
+		return apperror.ErrInvalidCopyTransition{}
+	}
+
+	if status == entity.CopyStatusWithdrawn {
+		return uc.copyRepo.SetCopyStatus(ctx, bookCopy.Id, entity.CopyStatusWithdrawn)
+	}
+
+	unresolved, err := uc.borrowRepo.HasUnresolvedBorrowByCopy(ctx, bookCopy.Id)
+	if err != nil {
+		return err
+	}
+	if unresolved {
+		return apperror.ErrCopyHasUnresolvedLoan{}
+	}
+
+	return allocateCopy(ctx, uc.holdRepo, uc.copyRepo, bookCopy.BookId, bookCopy.Id, uc.loanConfig.HoldPickupDays)
+}
@@ -0,0 +1,304 @@
+package usecase_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListBookCopiesUsecase(t *testing.T) {
+	t.Run("should return the copies of the book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockCopyRepo.On("ListCopiesByBook", ctx, bookId).Return([]entity.BookCopy{
+			{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "available"},
+			{Id: 2, BookId: bookId, Barcode: "BK000001-002", Condition: "fair", Status: "borrowed"},
+		}, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, mockBookRepo, new(mocks.BorrowRepo), new(mocks.HoldRepo), new(mocks.TransactionRepo), loanConfig)
+
+		copies, err := copyUsecase.ListBookCopies(ctx, bookId)
+
+		assert.Nil(t, err)
+		assert.Len(t, copies, 2)
+		assert.Equal(t, "BK000001-002", copies[1].Barcode)
+	})
+
+	t.Run("should return ErrBookNotFound when the book does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(false, nil)
+		copyUsecase := usecase.NewCopyUsecase(new(mocks.CopyRepo), mockBookRepo, new(mocks.BorrowRepo), new(mocks.HoldRepo), new(mocks.TransactionRepo), loanConfig)
+
+		_, err := copyUsecase.ListBookCopies(ctx, bookId)
+
+		assert.Equal(t, apperror.ErrBookNotFound{}, err)
+	})
+}
+
+func TestAddCopyUsecase(t *testing.T) {
+	copyRequest := &dto.CopyRequest{Barcode: "BK000001-003", Location: "A1"}
+
+	t.Run("should reserve the new copy for the first waiting hold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, copyRequest.Barcode).Return(false, nil)
+		mockCopyRepo.On("AddCopy", ctx, &entity.BookCopy{BookId: bookId, Barcode: copyRequest.Barcode, Condition: entity.CopyConditionGood, Location: "A1"}).
+			Return(&entity.BookCopy{Id: 3, BookId: bookId, Barcode: copyRequest.Barcode, Condition: entity.CopyConditionGood, Location: "A1", Status: entity.CopyStatusAvailable}, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, bookId, 3, loanConfig.HoldPickupDays).Return(true, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 3, entity.CopyStatusReserved).Return(nil)
+		mockCopyRepo.On("GetCopyById", ctx, 3).Return(&entity.BookCopy{Id: 3, BookId: bookId, Barcode: copyRequest.Barcode, Condition: entity.CopyConditionGood, Location: "A1", Status: entity.CopyStatusReserved}, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, mockBookRepo, new(mocks.BorrowRepo), mockHoldRepo, mockTxRepo, loanConfig)
+
+		copyResponse, err := copyUsecase.AddCopy(ctx, bookId, copyRequest)
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyStatusReserved, copyResponse.Status)
+	})
+
+	t.Run("should return ErrDuplicateBarcode when the barcode is already used", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errDuplicateBarcode := apperror.ErrDuplicateBarcode{}
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errDuplicateBarcode
+			}),
+		).Return(errDuplicateBarcode)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockCopyRepo.On("IsBarcodeExisted", ctx, copyRequest.Barcode).Return(true, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, mockBookRepo, new(mocks.BorrowRepo), new(mocks.HoldRepo), mockTxRepo, loanConfig)
+
+		_, err := copyUsecase.AddCopy(ctx, bookId, copyRequest)
+
+		assert.Equal(t, errDuplicateBarcode, err)
+		mockCopyRepo.AssertNotCalled(t, "AddCopy", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPatchCopyUsecase(t *testing.T) {
+	t.Run("should update the condition keeping the location", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		condition := entity.CopyConditionPoor
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(true, nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Location: "A1", Status: "available"}, nil)
+		mockCopyRepo.On("UpdateCopy", ctx, &entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: condition, Location: "A1", Status: "available"}).
+			Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: condition, Location: "A1", Status: "available"}, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), new(mocks.BorrowRepo), new(mocks.HoldRepo), mockTxRepo, loanConfig)
+
+		copyResponse, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{Condition: &condition})
+
+		assert.Nil(t, err)
+		assert.Equal(t, condition, copyResponse.Condition)
+		assert.Equal(t, "A1", copyResponse.Location)
+	})
+
+	t.Run("should withdraw an available copy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		status := entity.CopyStatusWithdrawn
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(true, nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "available"}, nil).Once()
+		mockCopyRepo.On("UpdateCopy", ctx, mock.Anything).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "available"}, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 1, entity.CopyStatusWithdrawn).Return(nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "withdrawn"}, nil).Once()
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), new(mocks.BorrowRepo), new(mocks.HoldRepo), mockTxRepo, loanConfig)
+
+		copyResponse, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{Status: &status})
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyStatusWithdrawn, copyResponse.Status)
+	})
+
+	t.Run("should reserve a copy put back on the shelf for the first waiting hold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		status := entity.CopyStatusAvailable
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(true, nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "withdrawn"}, nil).Once()
+		mockCopyRepo.On("UpdateCopy", ctx, mock.Anything).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "withdrawn"}, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, bookId, 1, loanConfig.HoldPickupDays).Return(true, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 1, entity.CopyStatusReserved).Return(nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "reserved"}, nil).Once()
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("HasUnresolvedBorrowByCopy", ctx, 1).Return(false, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), mockBorrowRepo, mockHoldRepo, mockTxRepo, loanConfig)
+
+		copyResponse, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{Status: &status})
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyStatusReserved, copyResponse.Status)
+	})
+
+	t.Run("should put a lost copy back on the shelf once no loan is left on it", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		status := entity.CopyStatusAvailable
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(true, nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "lost"}, nil).Once()
+		mockCopyRepo.On("UpdateCopy", ctx, mock.Anything).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "lost"}, nil)
+		mockBorrowRepo.On("HasUnresolvedBorrowByCopy", ctx, 1).Return(false, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, bookId, 1, loanConfig.HoldPickupDays).Return(false, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 1, entity.CopyStatusAvailable).Return(nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "available"}, nil).Once()
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), mockBorrowRepo, mockHoldRepo, mockTxRepo, loanConfig)
+
+		copyResponse, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{Status: &status})
+
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CopyStatusAvailable, copyResponse.Status)
+	})
+
+	t.Run("should return ErrCopyHasUnresolvedLoan when a lost loan still points at the copy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		status := entity.CopyStatusAvailable
+		errCopyHasUnresolvedLoan := apperror.ErrCopyHasUnresolvedLoan{}
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errCopyHasUnresolvedLoan
+			}),
+		).Return(errCopyHasUnresolvedLoan)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(true, nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "lost"}, nil)
+		mockCopyRepo.On("UpdateCopy", ctx, mock.Anything).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "lost"}, nil)
+		mockBorrowRepo.On("HasUnresolvedBorrowByCopy", ctx, 1).Return(true, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), mockBorrowRepo, mockHoldRepo, mockTxRepo, loanConfig)
+
+		_, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{Status: &status})
+
+		assert.Equal(t, errCopyHasUnresolvedLoan, err)
+		mockCopyRepo.AssertNotCalled(t, "SetCopyStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockHoldRepo.AssertNotCalled(t, "ReadyNextHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrInvalidCopyTransition when withdrawing a borrowed copy", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		status := entity.CopyStatusWithdrawn
+		errInvalidCopyTransition := apperror.ErrInvalidCopyTransition{}
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errInvalidCopyTransition
+			}),
+		).Return(errInvalidCopyTransition)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(true, nil)
+		mockCopyRepo.On("GetCopyById", ctx, 1).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "borrowed"}, nil)
+		mockCopyRepo.On("UpdateCopy", ctx, mock.Anything).Return(&entity.BookCopy{Id: 1, BookId: bookId, Barcode: "BK000001-001", Condition: "good", Status: "borrowed"}, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), new(mocks.BorrowRepo), new(mocks.HoldRepo), mockTxRepo, loanConfig)
+
+		_, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{Status: &status})
+
+		assert.Equal(t, errInvalidCopyTransition, err)
+		mockCopyRepo.AssertNotCalled(t, "SetCopyStatus", ctx, 1, entity.CopyStatusWithdrawn)
+	})
+
+	t.Run("should return ErrCopyNotFound when the copy does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errCopyNotFound := apperror.ErrCopyNotFound{}
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errCopyNotFound
+			}),
+		).Return(errCopyNotFound)
+		mockCopyRepo.On("IsCopyExisted", ctx, 1).Return(false, nil)
+		copyUsecase := usecase.NewCopyUsecase(mockCopyRepo, new(mocks.BookRepo), new(mocks.BorrowRepo), new(mocks.HoldRepo), mockTxRepo, loanConfig)
+
+		_, err := copyUsecase.PatchCopy(ctx, 1, &dto.CopyPatchRequest{})
+
+		assert.Equal(t, errCopyNotFound, err)
+	})
+}
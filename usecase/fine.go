@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/repo"
+	"archive_lib/util/tracing"
+	"context"
+)
+
+type FineUsecase interface {
+	GetUserFines(ctx context.Context, userId int) (*dto.FinesResponse, error)
+	RecordPayment(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error)
+	WaiveFine(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error)
+}
+
+type fineUsecaseImpl struct {
+	fineRepo repo.FineRepo
+	userRepo repo.UserRepo
+	txRepo   repo.TransactionRepo
+}
+
+func NewFineUsecase(fineRepo repo.FineRepo, userRepo repo.UserRepo, txRepo repo.TransactionRepo) fineUsecaseImpl {
+	return fineUsecaseImpl{
+		fineRepo: fineRepo,
+		userRepo: userRepo,
+		txRepo:   txRepo,
+	}
+}
+
+func convertFineEntryToRes(entry *entity.FineEntry) *dto.FineEntryResponse {
+	return &dto.FineEntryResponse{
+		Id:        entry.Id,
+		BorrowId:  entry.BorrowId,
+		Kind:      entry.Kind,
+		Amount:    entry.Amount,
+		Note:      entry.Note,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func (uc fineUsecaseImpl) GetUserFines(ctx context.Context, userId int) (*dto.FinesResponse, error) {
+	ctx, span := tracing.Start(ctx, "FineUsecase.GetUserFines")
+	defer span.End()
+
+	balance, err := uc.fineRepo.GetBalance(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.fineRepo.ListFineEntries(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	finesResponse := &dto.FinesResponse{
+		Balance: balance,
+		Entries: []dto.FineEntryResponse{},
+	}
+	for i := range entries {
+		finesResponse.Entries = append(finesResponse.Entries, *convertFineEntryToRes(&entries[i]))
+	}
+
+	return finesResponse, nil
+}
+
+func (uc fineUsecaseImpl) RecordPayment(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error) {
+	ctx, span := tracing.Start(ctx, "FineUsecase.RecordPayment")
+	defer span.End()
+
+	return uc.settle(ctx, entity.FineKindPayment, userId, staffId, request)
+}
+
+func (uc fineUsecaseImpl) WaiveFine(ctx context.Context, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error) {
+	ctx, span := tracing.Start(ctx, "FineUsecase.WaiveFine")
+	defer span.End()
+
+	return uc.settle(ctx, entity.FineKindWaiver, userId, staffId, request)
+}
+
+func (uc fineUsecaseImpl) settle(ctx context.Context, kind string, userId int, staffId int, request *dto.FineSettlementRequest) (*dto.FineEntryResponse, error) {
+	var entry *entity.FineEntry
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		// the lock keeps two settlements from both passing the same balance
+		found, err := uc.userRepo.LockUser(txCtx, userId)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrUserNotFound{}
+		}
+
+		balance, err := uc.fineRepo.GetBalance(txCtx, userId)
+		if err != nil {
+			return err
+		}
+		if request.Amount > balance {
+			return apperror.ErrAmountExceedsBalance{}
+		}
+
+		entry, err = uc.fineRepo.AddFineEntry(txCtx, &entity.FineEntry{
+			UserId:    userId,
+			Kind:      kind,
+			Amount:    request.Amount,
+			Note:      request.Note,
+			CreatedBy: &staffId,
+		})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return convertFineEntryToRes(entry), nil
+}
@@ -0,0 +1,132 @@
+package usecase_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetUserFinesUsecase(t *testing.T) {
+	t.Run("should return the balance with the ledger entries", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockFineRepo := new(mocks.FineRepo)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(100), nil)
+		mockFineRepo.On("ListFineEntries", ctx, 1).Return([]entity.FineEntry{
+			{Id: 1, UserId: 1, Kind: entity.FineKindCharge, Amount: 150},
+			{Id: 2, UserId: 1, Kind: entity.FineKindPayment, Amount: 50},
+		}, nil)
+		fineUsecase := usecase.NewFineUsecase(mockFineRepo, new(mocks.UserRepo), new(mocks.TransactionRepo))
+
+		finesResponse, err := fineUsecase.GetUserFines(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(100), finesResponse.Balance)
+		assert.Len(t, finesResponse.Entries, 2)
+	})
+
+	t.Run("should return error when balance checking encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockFineRepo := new(mocks.FineRepo)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(0), errors.New("error"))
+		fineUsecase := usecase.NewFineUsecase(mockFineRepo, new(mocks.UserRepo), new(mocks.TransactionRepo))
+
+		_, err := fineUsecase.GetUserFines(ctx, 1)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSettleFineUsecase(t *testing.T) {
+	settlement := &dto.FineSettlementRequest{Amount: 100, Note: "cash"}
+	staffId := 2
+
+	t.Run("should record the payment made by the staff when no error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockFineRepo := new(mocks.FineRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(150), nil)
+		mockFineRepo.On("AddFineEntry", ctx, &entity.FineEntry{
+			UserId:    1,
+			Kind:      entity.FineKindPayment,
+			Amount:    100,
+			Note:      "cash",
+			CreatedBy: &staffId,
+		}).Return(&entity.FineEntry{Id: 3, UserId: 1, Kind: entity.FineKindPayment, Amount: 100, Note: "cash"}, nil)
+		fineUsecase := usecase.NewFineUsecase(mockFineRepo, mockUserRepo, mockTxRepo)
+
+		entryResponse, err := fineUsecase.RecordPayment(ctx, 1, staffId, settlement)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &dto.FineEntryResponse{Id: 3, Kind: entity.FineKindPayment, Amount: 100, Note: "cash"}, entryResponse)
+	})
+
+	t.Run("should return ErrAmountExceedsBalance when waiving more than the balance", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errAmountExceedsBalance := apperror.ErrAmountExceedsBalance{}
+		mockFineRepo := new(mocks.FineRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errAmountExceedsBalance
+			}),
+		).Return(errAmountExceedsBalance)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(50), nil)
+		fineUsecase := usecase.NewFineUsecase(mockFineRepo, mockUserRepo, mockTxRepo)
+
+		_, err := fineUsecase.WaiveFine(ctx, 1, staffId, settlement)
+
+		assert.Equal(t, errAmountExceedsBalance, err)
+		mockFineRepo.AssertNotCalled(t, "AddFineEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return ErrUserNotFound when settling fines of a non-existent user", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errUserNotFound := apperror.ErrUserNotFound{}
+		mockUserRepo := new(mocks.UserRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errUserNotFound
+			}),
+		).Return(errUserNotFound)
+		mockUserRepo.On("LockUser", ctx, 1).Return(false, nil)
+		fineUsecase := usecase.NewFineUsecase(new(mocks.FineRepo), mockUserRepo, mockTxRepo)
+
+		_, err := fineUsecase.RecordPayment(ctx, 1, staffId, settlement)
+
+		assert.Equal(t, errUserNotFound, err)
+	})
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/migration"
+	"archive_lib/repo"
+	"archive_lib/util/tracing"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+type MigrationChecker interface {
+	Pending(ctx context.Context) ([]migration.Migration, error)
+}
+
+type HealthUsecase interface {
+	Ready(ctx context.Context) error
+	Status(ctx context.Context) *dto.StatusResponse
+	StartDraining()
+}
+
+type healthUsecaseImpl struct {
+	healthRepo       repo.HealthRepo
+	migrationChecker MigrationChecker
+	build            dto.BuildInfo
+	startedAt        time.Time
+	draining         *atomic.Bool
+}
+
+func NewHealthUsecase(healthRepo repo.HealthRepo, migrationChecker MigrationChecker, build dto.BuildInfo) healthUsecaseImpl {
+	return healthUsecaseImpl{
+		healthRepo:       healthRepo,
+		migrationChecker: migrationChecker,
+		build:            build,
+		startedAt:        time.Now(),
+		draining:         &atomic.Bool{},
+	}
+}
+
+// StartDraining makes the service report itself as not ready from now on, so load balancers
+// stop sending it traffic before the server shuts down.
+func (uc healthUsecaseImpl) StartDraining() {
+	uc.draining.Store(true)
+}
+
+func (uc healthUsecaseImpl) Ready(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "HealthUsecase.Ready")
+	defer span.End()
+
+	if uc.draining.Load() {
+		return apperror.ErrShuttingDown{}
+	}
+
+	for _, component := range uc.checkComponents(ctx) {
+		if component.Status != entity.HealthUp {
+			return apperror.ErrNotReady{}
+		}
+	}
+
+	return nil
+}
+
+func (uc healthUsecaseImpl) Status(ctx context.Context) *dto.StatusResponse {
+	ctx, span := tracing.Start(ctx, "HealthUsecase.Status")
+	defer span.End()
+
+	components := uc.checkComponents(ctx)
+
+	status := entity.HealthUp
+	for _, component := range components {
+		if component.Status != entity.HealthUp {
+			status = entity.HealthDown
+		}
+	}
+	if uc.draining.Load() {
+		status = entity.HealthDraining
+	}
+
+	return &dto.StatusResponse{
+		Status:     status,
+		Uptime:     time.Since(uc.startedAt).Round(time.Second).String(),
+		Build:      uc.build,
+		Components: components,
+	}
+}
+
+// checkComponents checks the database, then that every migration has been applied.
+func (uc healthUsecaseImpl) checkComponents(ctx context.Context) []dto.ComponentStatus {
+	return []dto.ComponentStatus{
+		check(ctx, "database", uc.healthRepo.Ping),
+		check(ctx, "migrations", func(ctx context.Context) error {
+			pending, err := uc.migrationChecker.Pending(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending migration(s)", len(pending))
+			}
+			return nil
+		}),
+	}
+}
+
+func check(ctx context.Context, name string, fn func(ctx context.Context) error) dto.ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	startTime := time.Now()
+	err := fn(ctx)
+	component := dto.ComponentStatus{
+		Name:      name,
+		Status:    entity.HealthUp,
+		LatencyMs: time.Since(startTime).Milliseconds(),
+	}
+	if err != nil {
+		component.Status = entity.HealthDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/migration"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadyUsecase(t *testing.T) {
+	build := dto.BuildInfo{Version: "dev", GoVersion: "go1.19"}
+
+	t.Run("should return nil when the database is up and every migration is applied", func(t *testing.T) {
+		ctx := context.Background()
+		mockHealthRepo := new(mocks.HealthRepo)
+		mockMigrationChecker := new(mocks.MigrationChecker)
+		mockHealthRepo.On("Ping", mock.Anything).Return(nil)
+		mockMigrationChecker.On("Pending", mock.Anything).Return([]migration.Migration{}, nil)
+		healthUsecase := usecase.NewHealthUsecase(mockHealthRepo, mockMigrationChecker, build)
+
+		err := healthUsecase.Ready(ctx)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return ErrNotReady when migrations are pending", func(t *testing.T) {
+		ctx := context.Background()
+		mockHealthRepo := new(mocks.HealthRepo)
+		mockMigrationChecker := new(mocks.MigrationChecker)
+		mockHealthRepo.On("Ping", mock.Anything).Return(nil)
+		mockMigrationChecker.On("Pending", mock.Anything).Return([]migration.Migration{{Version: 2, Name: "add_index"}}, nil)
+		healthUsecase := usecase.NewHealthUsecase(mockHealthRepo, mockMigrationChecker, build)
+
+		err := healthUsecase.Ready(ctx)
+
+		assert.ErrorIs(t, err, apperror.ErrNotReady{})
+	})
+
+	t.Run("should return ErrShuttingDown once draining started", func(t *testing.T) {
+		ctx := context.Background()
+		mockHealthRepo := new(mocks.HealthRepo)
+		mockMigrationChecker := new(mocks.MigrationChecker)
+		healthUsecase := usecase.NewHealthUsecase(mockHealthRepo, mockMigrationChecker, build)
+
+		healthUsecase.StartDraining()
+		err := healthUsecase.Ready(ctx)
+
+		assert.ErrorIs(t, err, apperror.ErrShuttingDown{})
+		mockHealthRepo.AssertNotCalled(t, "Ping", mock.Anything)
+	})
+}
+
+func TestStatusUsecase(t *testing.T) {
+	build := dto.BuildInfo{Version: "dev", GoVersion: "go1.19"}
+
+	t.Run("should report the failing component when the database is down", func(t *testing.T) {
+		ctx := context.Background()
+		mockHealthRepo := new(mocks.HealthRepo)
+		mockMigrationChecker := new(mocks.MigrationChecker)
+		mockHealthRepo.On("Ping", mock.Anything).Return(errors.New("connection refused"))
+		mockMigrationChecker.On("Pending", mock.Anything).Return(nil, errors.New("connection refused"))
+		healthUsecase := usecase.NewHealthUsecase(mockHealthRepo, mockMigrationChecker, build)
+
+		statusResponse := healthUsecase.Status(ctx)
+
+		assert.Equal(t, entity.HealthDown, statusResponse.Status)
+		assert.Equal(t, build, statusResponse.Build)
+		assert.Equal(t, "database", statusResponse.Components[0].Name)
+		assert.Equal(t, entity.HealthDown, statusResponse.Components[0].Status)
+		assert.Equal(t, "connection refused", statusResponse.Components[0].Error)
+	})
+
+	t.Run("should report draining once draining started", func(t *testing.T) {
+		ctx := context.Background()
+		mockHealthRepo := new(mocks.HealthRepo)
+		mockMigrationChecker := new(mocks.MigrationChecker)
+		mockHealthRepo.On("Ping", mock.Anything).Return(nil)
+		mockMigrationChecker.On("Pending", mock.Anything).Return([]migration.Migration{}, nil)
+		healthUsecase := usecase.NewHealthUsecase(mockHealthRepo, mockMigrationChecker, build)
+
+		healthUsecase.StartDraining()
+		statusResponse := healthUsecase.Status(ctx)
+
+		assert.Equal(t, entity.HealthDraining, statusResponse.Status)
+		assert.Equal(t, entity.HealthUp, statusResponse.Components[1].Status)
+	})
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/repo"
+	"archive_lib/util/tracing"
+	"context"
+)
+
+type HoldUsecase interface {
+	PlaceHold(ctx context.Context, bookId int, userId int) (*dto.HoldResponse, error)
+	ListUserHolds(ctx context.Context, userId int) ([]dto.HoldResponse, error)
+	ListBookHolds(ctx context.Context, bookId int) ([]dto.HoldResponse, error)
+	CancelHold(ctx context.Context, id int, userId int) error
+}
+
+type holdUsecaseImpl struct {
+	holdRepo   repo.HoldRepo
+	bookRepo   repo.BookRepo
+	copyRepo   repo.CopyRepo
+	txRepo     repo.TransactionRepo
+	loanConfig LoanConfig
+}
+
+func NewHoldUsecase(holdRepo repo.HoldRepo, bookRepo repo.BookRepo, copyRepo repo.CopyRepo, txRepo repo.TransactionRepo, loanConfig LoanConfig) holdUsecaseImpl {
+	return holdUsecaseImpl{
+		holdRepo:   holdRepo,
+		bookRepo:   bookRepo,
+		copyRepo:   copyRepo,
+		txRepo:     txRepo,
+		loanConfig: loanConfig,
+	}
+}
+
+// allocateCopy hands a copy coming back to the shelf to the next hold in the queue,
+// or makes it available again when nobody is waiting.
+func allocateCopy(ctx context.Context, holdRepo repo.HoldRepo, copyRepo repo.CopyRepo, bookId int, copyId int, pickupDays int) error {
+	allocated, err := holdRepo.ReadyNextHold(ctx, bookId, copyId, pickupDays)
+	if err != nil {
+		return err
+	}
+	if allocated {
+		return copyRepo.SetCopyStatus(ctx, copyId, entity.CopyStatusReserved)
+	}
+
+	return copyRepo.SetCopyStatus(ctx, copyId, entity.CopyStatusAvailable)
+}
+
+// releaseExpiredHolds reallocates the copies reserved for holds whose pickup window has passed.
+func releaseExpiredHolds(ctx context.Context, holdRepo repo.HoldRepo, copyRepo repo.CopyRepo, bookId int, pickupDays int) error {
+	copyIds, err := holdRepo.ExpireReadyHolds(ctx, bookId)
+	if err != nil {
+		return err
+	}
+
+	for _, copyId := range copyIds {
+		err = allocateCopy(ctx, holdRepo, copyRepo, bookId, copyId, pickupDays)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func convertHoldToHoldRes(hold *entity.Hold) *dto.HoldResponse {
+	holdResponse := &dto.HoldResponse{
+		Id:        hold.Id,
+		BookId:    hold.BookId,
+		UserId:    hold.UserId,
+		CopyId:    hold.CopyId,
+		Status:    hold.Status,
+		CreatedAt: hold.CreatedAt,
+	}
+	if !hold.ExpiresAt.IsZero() {
+		expiresAt := hold.ExpiresAt
+		holdResponse.ExpiresAt = &expiresAt
+	}
+
+	return holdResponse
+}
+
+func convertHoldsToHoldRes(holds []entity.Hold) []dto.HoldResponse {
+	holdResponses := []dto.HoldResponse{}
+	for i := range holds {
+		holdResponses = append(holdResponses, *convertHoldToHoldRes(&holds[i]))
+	}
+
+	return holdResponses
+}
+
+func (uc holdUsecaseImpl) PlaceHold(ctx context.Context, bookId int, userId int) (*dto.HoldResponse, error) {
+	ctx, span := tracing.Start(ctx, "HoldUsecase.PlaceHold")
+	defer span.End()
+
+	var hold *entity.Hold
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.bookRepo.IsBookExisted(txCtx, bookId)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrBookNotFound{}
+		}
+
+		err = releaseExpiredHolds(txCtx, uc.holdRepo, uc.copyRepo, bookId, uc.loanConfig.HoldPickupDays)
+		if err != nil {
+			return err
+		}
+
+		available, err := uc.bookRepo.IsStockAvailable(txCtx, bookId)
+		if err != nil {
+			return err
+		}
+		if available {
+			return apperror.ErrStockAvailable{}
+		}
+
+		duplicate, err := uc.holdRepo.IsActiveHoldExisted(txCtx, bookId, userId)
+		if err != nil {
+			return err
+		}
+		if duplicate {
+			return apperror.ErrDuplicateHold{}
+		}
+
+		hold, err = uc.holdRepo.AddHold(txCtx, &entity.Hold{BookId: bookId, UserId: userId})
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return convertHoldToHoldRes(hold), nil
+}
+
+func (uc holdUsecaseImpl) ListUserHolds(ctx context.Context, userId int) ([]dto.HoldResponse, error) {
+	ctx, span := tracing.Start(ctx, "HoldUsecase.ListUserHolds")
+	defer span.End()
+
+	holds, err := uc.holdRepo.ListHoldsByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	// a ready hold may have passed its pickup window since the last borrow or hold on its book
+	released := false
+	for _, hold := range holds {
+		if hold.Status != entity.HoldStatusReady {
+			continue
+		}
+
+		err = uc.expireHolds(ctx, hold.BookId)
+		if err != nil {
+			return nil, err
+		}
+		released = true
+	}
+
+	if released {
+		holds, err = uc.holdRepo.ListHoldsByUser(ctx, userId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return convertHoldsToHoldRes(holds), nil
+}
+
+func (uc holdUsecaseImpl) ListBookHolds(ctx context.Context, bookId int) ([]dto.HoldResponse, error) {
+	ctx, span := tracing.Start(ctx, "HoldUsecase.ListBookHolds")
+	defer span.End()
+
+	found, err := uc.bookRepo.IsBookExisted(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperror.ErrBookNotFound{}
+	}
+
+	err = uc.expireHolds(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := uc.holdRepo.ListHoldsByBook(ctx, bookId)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertHoldsToHoldRes(holds), nil
+}
+
+// expireHolds reallocates the copies of the expired holds of the book in a transaction of its own,
+// so the holds listed afterwards are up to date.
+func (uc holdUsecaseImpl) expireHolds(ctx context.Context, bookId int) error {
+	return uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return releaseExpiredHolds(txCtx, uc.holdRepo, uc.copyRepo, bookId, uc.loanConfig.HoldPickupDays)
+	})
+}
+
+func (uc holdUsecaseImpl) CancelHold(ctx context.Context, id int, userId int) error {
+	ctx, span := tracing.Start(ctx, "HoldUsecase.CancelHold")
+	defer span.End()
+
+	return uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.holdRepo.IsHoldExisted(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrHoldNotFound{}
+		}
+
+		hold, err := uc.holdRepo.GetHoldById(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if hold.UserId != userId {
+			return apperror.ErrHoldNotFound{}
+		}
+		if !hold.IsActive() {
+			return apperror.ErrHoldNotActive{}
+		}
+
+		err = uc.holdRepo.CancelHold(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		// the copy reserved for a ready hold moves on to the next member in the queue
+		if hold.Status == entity.HoldStatusReady {
+			return allocateCopy(txCtx, uc.holdRepo, uc.copyRepo, hold.BookId, hold.CopyId, uc.loanConfig.HoldPickupDays)
+		}
+
+		return nil
+	})
+}
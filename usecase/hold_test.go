@@ -0,0 +1,303 @@
+package usecase_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/dto"
+	"archive_lib/entity"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPlaceHoldUsecase(t *testing.T) {
+	createdAt := time.Now()
+
+	t.Run("should return the waiting hold when the book is out of stock", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, 1).Return([]int{}, nil)
+		mockBookRepo.On("IsStockAvailable", ctx, 1).Return(false, nil)
+		mockHoldRepo.On("IsActiveHoldExisted", ctx, 1, 2).Return(false, nil)
+		mockHoldRepo.On("AddHold", ctx, &entity.Hold{BookId: 1, UserId: 2}).Return(&entity.Hold{
+			Id:        1,
+			BookId:    1,
+			UserId:    2,
+			Status:    "waiting",
+			CreatedAt: createdAt,
+		}, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, mockBookRepo, new(mocks.CopyRepo), mockTxRepo, loanConfig)
+		expectedResponse := &dto.HoldResponse{Id: 1, BookId: 1, UserId: 2, Status: "waiting", CreatedAt: createdAt}
+
+		holdResponse, err := holdUsecase.PlaceHold(ctx, 1, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponse, holdResponse)
+	})
+
+	t.Run("should return ErrStockAvailable when the book can be borrowed directly", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errStockAvailable := apperror.ErrStockAvailable{}
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errStockAvailable
+			}),
+		).Return(errStockAvailable)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, 1).Return([]int{}, nil)
+		mockBookRepo.On("IsStockAvailable", ctx, 1).Return(true, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, mockBookRepo, new(mocks.CopyRepo), mockTxRepo, loanConfig)
+
+		_, err := holdUsecase.PlaceHold(ctx, 1, 2)
+
+		assert.Equal(t, errStockAvailable, err)
+	})
+
+	t.Run("should return ErrDuplicateHold when the user already holds the book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errDuplicateHold := apperror.ErrDuplicateHold{}
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errDuplicateHold
+			}),
+		).Return(errDuplicateHold)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, 1).Return([]int{}, nil)
+		mockBookRepo.On("IsStockAvailable", ctx, 1).Return(false, nil)
+		mockHoldRepo.On("IsActiveHoldExisted", ctx, 1, 2).Return(true, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, mockBookRepo, new(mocks.CopyRepo), mockTxRepo, loanConfig)
+
+		_, err := holdUsecase.PlaceHold(ctx, 1, 2)
+
+		assert.Equal(t, errDuplicateHold, err)
+	})
+
+	t.Run("should reallocate copies of expired holds before checking the stock", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errStockAvailable := apperror.ErrStockAvailable{}
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errStockAvailable
+			}),
+		).Return(errStockAvailable)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, 1).Return([]int{5}, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, 1, 5, loanConfig.HoldPickupDays).Return(false, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 5, entity.CopyStatusAvailable).Return(nil)
+		mockBookRepo.On("IsStockAvailable", ctx, 1).Return(true, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, mockBookRepo, mockCopyRepo, mockTxRepo, loanConfig)
+
+		_, err := holdUsecase.PlaceHold(ctx, 1, 2)
+
+		assert.Equal(t, errStockAvailable, err)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, 5, entity.CopyStatusAvailable)
+	})
+}
+
+func TestListHoldsUsecase(t *testing.T) {
+	createdAt := time.Now()
+
+	t.Run("should release the expired ready holds of the user before listing them again", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockHoldRepo.On("ListHoldsByUser", ctx, 2).Return([]entity.Hold{
+			{Id: 1, BookId: 1, UserId: 2, CopyId: 5, Status: "ready", CreatedAt: createdAt},
+			{Id: 2, BookId: 3, UserId: 2, Status: "waiting", CreatedAt: createdAt},
+		}, nil).Once()
+		mockHoldRepo.On("ExpireReadyHolds", ctx, 1).Return([]int{5}, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, 1, 5, loanConfig.HoldPickupDays).Return(false, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 5, entity.CopyStatusAvailable).Return(nil)
+		mockHoldRepo.On("ListHoldsByUser", ctx, 2).Return([]entity.Hold{
+			{Id: 1, BookId: 1, UserId: 2, Status: "expired", CreatedAt: createdAt},
+			{Id: 2, BookId: 3, UserId: 2, Status: "waiting", CreatedAt: createdAt},
+		}, nil).Once()
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, new(mocks.BookRepo), mockCopyRepo, mockTxRepo, loanConfig)
+		expectedResponse := []dto.HoldResponse{
+			{Id: 1, BookId: 1, UserId: 2, Status: "expired", CreatedAt: createdAt},
+			{Id: 2, BookId: 3, UserId: 2, Status: "waiting", CreatedAt: createdAt},
+		}
+
+		holdResponses, err := holdUsecase.ListUserHolds(ctx, 2)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponse, holdResponses)
+		mockHoldRepo.AssertNotCalled(t, "ExpireReadyHolds", ctx, 3)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, 5, entity.CopyStatusAvailable)
+	})
+
+	t.Run("should not list the holds of the user again when none is ready", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockHoldRepo.On("ListHoldsByUser", ctx, 2).Return([]entity.Hold{
+			{Id: 2, BookId: 3, UserId: 2, Status: "waiting", CreatedAt: createdAt},
+		}, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, new(mocks.BookRepo), new(mocks.CopyRepo), mockTxRepo, loanConfig)
+
+		_, err := holdUsecase.ListUserHolds(ctx, 2)
+
+		assert.Nil(t, err)
+		mockHoldRepo.AssertNumberOfCalls(t, "ListHoldsByUser", 1)
+		mockTxRepo.AssertNotCalled(t, "WithinTransaction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should pass the copy of an expired hold to the next hold before listing the queue of the book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockBookRepo := new(mocks.BookRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockBookRepo.On("IsBookExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("ExpireReadyHolds", ctx, 1).Return([]int{5}, nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, 1, 5, loanConfig.HoldPickupDays).Return(true, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 5, entity.CopyStatusReserved).Return(nil)
+		mockHoldRepo.On("ListHoldsByBook", ctx, 1).Return([]entity.Hold{
+			{Id: 2, BookId: 1, UserId: 3, Status: "ready", CreatedAt: createdAt},
+		}, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, mockBookRepo, mockCopyRepo, mockTxRepo, loanConfig)
+		expectedResponse := []dto.HoldResponse{{Id: 2, BookId: 1, UserId: 3, Status: "ready", CreatedAt: createdAt}}
+
+		holdResponses, err := holdUsecase.ListBookHolds(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedResponse, holdResponses)
+		mockCopyRepo.AssertCalled(t, "SetCopyStatus", ctx, 5, entity.CopyStatusReserved)
+	})
+}
+
+func TestCancelHoldUsecase(t *testing.T) {
+	t.Run("should pass the reserved copy to the next hold when cancelling a ready hold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockCopyRepo := new(mocks.CopyRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == nil
+			}),
+		).Return(nil)
+		mockHoldRepo.On("IsHoldExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("GetHoldById", ctx, 1).Return(&entity.Hold{Id: 1, BookId: 1, UserId: 2, CopyId: 5, Status: "ready"}, nil)
+		mockHoldRepo.On("CancelHold", ctx, 1).Return(nil)
+		mockHoldRepo.On("ReadyNextHold", ctx, 1, 5, loanConfig.HoldPickupDays).Return(true, nil)
+		mockCopyRepo.On("SetCopyStatus", ctx, 5, entity.CopyStatusReserved).Return(nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, new(mocks.BookRepo), mockCopyRepo, mockTxRepo, loanConfig)
+
+		err := holdUsecase.CancelHold(ctx, 1, 2)
+
+		assert.Nil(t, err)
+		mockHoldRepo.AssertCalled(t, "ReadyNextHold", ctx, 1, 5, loanConfig.HoldPickupDays)
+		mockCopyRepo.AssertNotCalled(t, "SetCopyStatus", ctx, 5, entity.CopyStatusAvailable)
+	})
+
+	t.Run("should return ErrHoldNotFound when cancelling the hold of another user", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errHoldNotFound := apperror.ErrHoldNotFound{}
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errHoldNotFound
+			}),
+		).Return(errHoldNotFound)
+		mockHoldRepo.On("IsHoldExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("GetHoldById", ctx, 1).Return(&entity.Hold{Id: 1, BookId: 1, UserId: 3, Status: "waiting"}, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, new(mocks.BookRepo), new(mocks.CopyRepo), mockTxRepo, loanConfig)
+
+		err := holdUsecase.CancelHold(ctx, 1, 2)
+
+		assert.Equal(t, errHoldNotFound, err)
+	})
+
+	t.Run("should return ErrHoldNotActive when cancelling a fulfilled hold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errHoldNotActive := apperror.ErrHoldNotActive{}
+		mockHoldRepo := new(mocks.HoldRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errHoldNotActive
+			}),
+		).Return(errHoldNotActive)
+		mockHoldRepo.On("IsHoldExisted", ctx, 1).Return(true, nil)
+		mockHoldRepo.On("GetHoldById", ctx, 1).Return(&entity.Hold{Id: 1, BookId: 1, UserId: 2, Status: "fulfilled"}, nil)
+		holdUsecase := usecase.NewHoldUsecase(mockHoldRepo, new(mocks.BookRepo), new(mocks.CopyRepo), mockTxRepo, loanConfig)
+
+		err := holdUsecase.CancelHold(ctx, 1, 2)
+
+		assert.Equal(t, errHoldNotActive, err)
+	})
+}
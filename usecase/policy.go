@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/entity"
+	"archive_lib/repo"
+	"context"
+)
+
+// BorrowPolicy decides whether a user may take out a loan. Policies run inside the
+// borrowing transaction, so they see the same state the loan is recorded against.
+type BorrowPolicy interface {
+	Check(ctx context.Context, borrow *entity.Borrow) error
+}
+
+// BorrowPolicies evaluates each policy in order and stops at the first violation.
+type BorrowPolicies []BorrowPolicy
+
+func (policies BorrowPolicies) Check(ctx context.Context, borrow *entity.Borrow) error {
+	for _, policy := range policies {
+		err := policy.Check(ctx, borrow)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewDefaultBorrowPolicies(borrowRepo repo.BorrowRepo, userRepo repo.UserRepo, fineRepo repo.FineRepo, loanConfig LoanConfig) BorrowPolicies {
+	return BorrowPolicies{
+		NewDuplicateLoanPolicy(borrowRepo),
+		NewOverdueLoanPolicy(borrowRepo),
+		NewUnpaidFinesPolicy(fineRepo, userRepo, loanConfig.FineThreshold),
+		NewMaxActiveLoansPolicy(borrowRepo, userRepo, loanConfig.MaxActiveLoans),
+	}
+}
+
+type duplicateLoanPolicy struct {
+	borrowRepo repo.BorrowRepo
+}
+
+func NewDuplicateLoanPolicy(borrowRepo repo.BorrowRepo) duplicateLoanPolicy {
+	return duplicateLoanPolicy{
+		borrowRepo: borrowRepo,
+	}
+}
+
+func (p duplicateLoanPolicy) Check(ctx context.Context, borrow *entity.Borrow) error {
+	borrowed, err := p.borrowRepo.HasActiveBorrow(ctx, borrow.UserId, borrow.BookId)
+	if err != nil {
+		return err
+	}
+	if borrowed {
+		return apperror.ErrDuplicateLoan{}
+	}
+
+	return nil
+}
+
+type overdueLoanPolicy struct {
+	borrowRepo repo.BorrowRepo
+}
+
+func NewOverdueLoanPolicy(borrowRepo repo.BorrowRepo) overdueLoanPolicy {
+	return overdueLoanPolicy{
+		borrowRepo: borrowRepo,
+	}
+}
+
+func (p overdueLoanPolicy) Check(ctx context.Context, borrow *entity.Borrow) error {
+	overdue, err := p.borrowRepo.HasOverdueBorrows(ctx, borrow.UserId)
+	if err != nil {
+		return err
+	}
+	if overdue {
+		return apperror.ErrHasOverdueLoans{}
+	}
+
+	return nil
+}
+
+type maxActiveLoansPolicy struct {
+	borrowRepo repo.BorrowRepo
+	userRepo   repo.UserRepo
+	limits     map[string]int
+}
+
+// NewMaxActiveLoansPolicy caps the loans a user may hold at once by role; roles without a limit are not capped.
+func NewMaxActiveLoansPolicy(borrowRepo repo.BorrowRepo, userRepo repo.UserRepo, limits map[string]int) maxActiveLoansPolicy {
+	return maxActiveLoansPolicy{
+		borrowRepo: borrowRepo,
+		userRepo:   userRepo,
+		limits:     limits,
+	}
+}
+
+func (p maxActiveLoansPolicy) Check(ctx context.Context, borrow *entity.Borrow) error {
+	// without the lock two loans of the same user could both be counted under the limit
+	found, err := p.userRepo.LockUser(ctx, borrow.UserId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperror.ErrUserNotFound{}
+	}
+
+	user, err := p.userRepo.GetUserById(ctx, borrow.UserId)
+	if err != nil {
+		return err
+	}
+
+	limit, ok := p.limits[user.Role]
+	if !ok {
+		return nil
+	}
+
+	count, err := p.borrowRepo.CountActiveBorrows(ctx, borrow.UserId)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return apperror.ErrLoanLimitReached{}
+	}
+
+	return nil
+}
+
+type unpaidFinesPolicy struct {
+	fineRepo  repo.FineRepo
+	userRepo  repo.UserRepo
+	threshold int64
+}
+
+// NewUnpaidFinesPolicy refuses loans to users owing more than the threshold, in minor units.
+func NewUnpaidFinesPolicy(fineRepo repo.FineRepo, userRepo repo.UserRepo, threshold int64) unpaidFinesPolicy {
+	return unpaidFinesPolicy{
+		fineRepo:  fineRepo,
+		userRepo:  userRepo,
+		threshold: threshold,
+	}
+}
+
+func (p unpaidFinesPolicy) Check(ctx context.Context, borrow *entity.Borrow) error {
+	// the balance is read under the same lock as the settlements, so it cannot change under the check
+	found, err := p.userRepo.LockUser(ctx, borrow.UserId)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperror.ErrUserNotFound{}
+	}
+
+	balance, err := p.fineRepo.GetBalance(ctx, borrow.UserId)
+	if err != nil {
+		return err
+	}
+	if balance > p.threshold {
+		return apperror.ErrUnpaidFines{}
+	}
+
+	return nil
+}
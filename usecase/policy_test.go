@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"archive_lib/apperror"
+	"archive_lib/entity"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBorrowPolicies(t *testing.T) {
+	loan := &entity.Borrow{UserId: 1, BookId: 1}
+
+	t.Run("should return nil when every policy allows the loan", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockFineRepo := new(mocks.FineRepo)
+		mockBorrowRepo.On("HasActiveBorrow", ctx, 1, 1).Return(false, nil)
+		mockBorrowRepo.On("HasOverdueBorrows", ctx, 1).Return(false, nil)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(0), nil)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockUserRepo.On("GetUserById", ctx, 1).Return(&entity.User{Id: 1, Role: "member"}, nil)
+		mockBorrowRepo.On("CountActiveBorrows", ctx, 1).Return(4, nil)
+		policies := usecase.NewDefaultBorrowPolicies(mockBorrowRepo, mockUserRepo, mockFineRepo, loanConfig)
+
+		err := policies.Check(ctx, loan)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return ErrDuplicateLoan when the user already borrows the book", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("HasActiveBorrow", ctx, 1, 1).Return(true, nil)
+		policy := usecase.NewDuplicateLoanPolicy(mockBorrowRepo)
+
+		err := policy.Check(ctx, loan)
+
+		assert.Equal(t, apperror.ErrDuplicateLoan{}, err)
+	})
+
+	t.Run("should return ErrHasOverdueLoans when the user has an overdue loan", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("HasOverdueBorrows", ctx, 1).Return(true, nil)
+		policy := usecase.NewOverdueLoanPolicy(mockBorrowRepo)
+
+		err := policy.Check(ctx, loan)
+
+		assert.Equal(t, apperror.ErrHasOverdueLoans{}, err)
+	})
+
+	t.Run("should return ErrUnpaidFines when the fine balance is over the threshold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockFineRepo := new(mocks.FineRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(150), nil)
+		policy := usecase.NewUnpaidFinesPolicy(mockFineRepo, mockUserRepo, 100)
+
+		err := policy.Check(ctx, loan)
+
+		assert.Equal(t, apperror.ErrUnpaidFines{}, err)
+	})
+
+	t.Run("should allow the loan when the fine balance is within the threshold", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockFineRepo := new(mocks.FineRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockFineRepo.On("GetBalance", ctx, 1).Return(int64(100), nil)
+		policy := usecase.NewUnpaidFinesPolicy(mockFineRepo, mockUserRepo, 100)
+
+		err := policy.Check(ctx, loan)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should return ErrLoanLimitReached when the user holds the maximum loans of the role", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockUserRepo.On("GetUserById", ctx, 1).Return(&entity.User{Id: 1, Role: "member"}, nil)
+		mockBorrowRepo.On("CountActiveBorrows", ctx, 1).Return(2, nil)
+		policy := usecase.NewMaxActiveLoansPolicy(mockBorrowRepo, mockUserRepo, map[string]int{"member": 2})
+
+		err := policy.Check(ctx, loan)
+
+		assert.Equal(t, apperror.ErrLoanLimitReached{}, err)
+	})
+
+	t.Run("should not cap the loans of a role without a limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockUserRepo.On("LockUser", ctx, 1).Return(true, nil)
+		mockUserRepo.On("GetUserById", ctx, 1).Return(&entity.User{Id: 1, Role: "librarian"}, nil)
+		policy := usecase.NewMaxActiveLoansPolicy(mockBorrowRepo, mockUserRepo, map[string]int{"member": 2})
+
+		err := policy.Check(ctx, loan)
+
+		assert.Nil(t, err)
+		mockBorrowRepo.AssertNotCalled(t, "CountActiveBorrows", ctx, 1)
+	})
+
+	t.Run("should return ErrUserNotFound when the borrower does not exist", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockUserRepo := new(mocks.UserRepo)
+		mockUserRepo.On("LockUser", ctx, 1).Return(false, nil)
+		policy := usecase.NewMaxActiveLoansPolicy(mockBorrowRepo, mockUserRepo, map[string]int{"member": 2})
+
+		err := policy.Check(ctx, loan)
+
+		assert.Equal(t, apperror.ErrUserNotFound{}, err)
+		mockBorrowRepo.AssertNotCalled(t, "CountActiveBorrows", ctx, 1)
+	})
+
+	t.Run("should return error when a policy lookup encounters error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockBorrowRepo.On("HasActiveBorrow", ctx, 1, 1).Return(false, errors.New("error"))
+		policies := usecase.BorrowPolicies{usecase.NewDuplicateLoanPolicy(mockBorrowRepo)}
+
+		err := policies.Check(ctx, loan)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRecordBorrowPolicyUsecase(t *testing.T) {
+	t.Run("should not record the loan when a policy is violated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		errHasOverdueLoans := apperror.ErrHasOverdueLoans{}
+		mockBookRepo := new(mocks.BookRepo)
+		mockBorrowRepo := new(mocks.BorrowRepo)
+		mockTxRepo := new(mocks.TransactionRepo)
+		mockTxRepo.On(
+			"WithinTransaction",
+			ctx,
+			mock.MatchedBy(func(txFn func(context.Context) error) bool {
+				err := txFn(ctx)
+				return err == errHasOverdueLoans
+			}),
+		).Return(errHasOverdueLoans)
+		mockBookRepo.On("IsBookExisted", ctx, bookId).Return(true, nil)
+		mockBorrowRepo.On("HasOverdueBorrows", ctx, 1).Return(true, nil)
+		policies := usecase.BorrowPolicies{usecase.NewOverdueLoanPolicy(mockBorrowRepo)}
+		borrowUsecase := usecase.NewBorrowUsecase(mockBorrowRepo, mockBookRepo, new(mocks.UserRepo), new(mocks.CopyRepo), new(mocks.HoldRepo), new(mocks.FineRepo), mockTxRepo, policies, loanConfig)
+
+		_, err := borrowUsecase.Record(ctx, borrowRequest)
+
+		assert.Equal(t, errHasOverdueLoans, err)
+		mockBorrowRepo.AssertNotCalled(t, "Record", ctx, borrow, loanConfig.LoanDays)
+	})
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"archive_lib/entity"
+	"archive_lib/repo"
+	"archive_lib/util/notifier"
+	"archive_lib/util/tracing"
+	"context"
+	"fmt"
+	"time"
+)
+
+const maxReminderRetryDelay = 24 * time.Hour
+
+// ReminderConfig sets which loans are reminded and how a failed reminder is retried: after RetryDelay,
+// doubled on every further failure up to a day, until it has been tried MaxAttempts times.
+type ReminderConfig struct {
+	DueSoonDays int
+	BatchSize   int
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+type ReminderUsecase interface {
+	SendReminders(ctx context.Context) (int, error)
+}
+
+type reminderUsecaseImpl struct {
+	notificationRepo repo.NotificationRepo
+	notifier         notifier.Notifier
+	reminderConfig   ReminderConfig
+}
+
+func NewReminderUsecase(notificationRepo repo.NotificationRepo, notifier notifier.Notifier, reminderConfig ReminderConfig) reminderUsecaseImpl {
+	return reminderUsecaseImpl{
+		notificationRepo: notificationRepo,
+		notifier:         notifier,
+		reminderConfig:   reminderConfig,
+	}
+}
+
+func buildReminderMessage(reminder *entity.Reminder) notifier.Message {
+	dueDate := reminder.DueDate.Format("2 January 2006")
+
+	if reminder.Kind == entity.NotificationOverdue {
+		return notifier.Message{
+			To:      reminder.Email,
+			Subject: fmt.Sprintf("Overdue: %s", reminder.BookTitle),
+			Body:    fmt.Sprintf("Hi %s,\n\n%s was due on %s. Please return it as soon as possible; a fine is charged for every day it is late.\n", reminder.Username, reminder.BookTitle, dueDate),
+		}
+	}
+
+	return notifier.Message{
+		To:      reminder.Email,
+		Subject: fmt.Sprintf("Due soon: %s", reminder.BookTitle),
+		Body:    fmt.Sprintf("Hi %s,\n\n%s is due on %s. Please return or renew it by then.\n", reminder.Username, reminder.BookTitle, dueDate),
+	}
+}
+
+// SendReminders notifies the borrowers of loans due soon or overdue and returns how many were sent.
+// Each reminder is claimed and committed before it is delivered, so that no transaction waits on the
+// mail server and a delivery is never repeated because a commit failed after it. A failed delivery is
+// marked for a retry and does not stop the others; the first error is returned. A reminder whose
+// delivery is interrupted before it can be marked is not sent again.
+func (uc reminderUsecaseImpl) SendReminders(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "ReminderUsecase.SendReminders")
+	defer span.End()
+
+	reminders, err := uc.notificationRepo.ListPendingReminders(ctx, uc.reminderConfig.DueSoonDays, uc.reminderConfig.MaxAttempts, uc.reminderConfig.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var firstErr error
+	sent := 0
+	for i := range reminders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		delivered, err := uc.sendReminder(ctx, &reminders[i])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if delivered {
+			sent++
+		}
+	}
+
+	return sent, firstErr
+}
+
+func (uc reminderUsecaseImpl) sendReminder(ctx context.Context, reminder *entity.Reminder) (bool, error) {
+	claimed, err := uc.notificationRepo.ClaimNotification(ctx, reminder, uc.reminderConfig.MaxAttempts)
+	if err != nil {
+		return false, err
+	}
+	// another instance got to it first
+	if !claimed {
+		return false, nil
+	}
+
+	err = uc.notifier.Notify(ctx, buildReminderMessage(reminder))
+	if err != nil {
+		markErr := uc.notificationRepo.MarkNotificationFailed(ctx, reminder.NotificationId, uc.retryDelay(reminder.Attempts), err.Error())
+		if markErr != nil {
+			return false, markErr
+		}
+		return false, err
+	}
+
+	return true, uc.notificationRepo.MarkNotificationSent(ctx, reminder.NotificationId)
+}
+
+// retryDelay doubles the delay before the next delivery with every failed attempt, up to maxReminderRetryDelay.
+func (uc reminderUsecaseImpl) retryDelay(attempts int) time.Duration {
+	delay := uc.reminderConfig.RetryDelay
+	for i := 1; i < attempts && delay < maxReminderRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxReminderRetryDelay {
+		return maxReminderRetryDelay
+	}
+	return delay
+}
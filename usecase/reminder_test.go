@@ -0,0 +1,138 @@
+package usecase_test
+
+import (
+	"archive_lib/entity"
+	"archive_lib/mocks"
+	"archive_lib/usecase"
+	"archive_lib/util/notifier"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var reminderConfig = usecase.ReminderConfig{
+	DueSoonDays: 2,
+	BatchSize:   100,
+	MaxAttempts: 5,
+	RetryDelay:  15 * time.Minute,
+}
+
+// claimReminder makes ClaimNotification claim the reminder as notification id, on its attempts-th try.
+func claimReminder(mockNotificationRepo *mocks.NotificationRepo, borrowId int, id int, attempts int) {
+	mockNotificationRepo.On("ClaimNotification", mock.Anything, mock.MatchedBy(func(reminder *entity.Reminder) bool {
+		return reminder.BorrowId == borrowId
+	}), 5).Return(true, nil).Run(func(args mock.Arguments) {
+		reminder := args.Get(1).(*entity.Reminder)
+		reminder.NotificationId = id
+		reminder.Attempts = attempts
+	})
+}
+
+func TestSendRemindersUsecase(t *testing.T) {
+	reminderDueDate := time.Date(2024, time.March, 8, 10, 0, 0, 0, time.UTC)
+	dueSoon := entity.Reminder{BorrowId: 1, UserId: 1, Username: "alice", Email: "alice@mail.com", BookTitle: "Dune", DueDate: reminderDueDate, Kind: entity.NotificationDueSoon}
+	overdue := entity.Reminder{BorrowId: 2, UserId: 2, Username: "bob", Email: "bob@mail.com", BookTitle: "Emma", DueDate: reminderDueDate, Kind: entity.NotificationOverdue}
+
+	t.Run("should notify the borrowers and mark every reminder sent", func(t *testing.T) {
+		ctx := context.Background()
+		mockNotificationRepo := new(mocks.NotificationRepo)
+		mockNotifier := new(mocks.Notifier)
+		mockNotificationRepo.On("ListPendingReminders", ctx, 2, 5, 100).Return([]entity.Reminder{dueSoon, overdue}, nil)
+		claimReminder(mockNotificationRepo, 1, 10, 1)
+		claimReminder(mockNotificationRepo, 2, 11, 1)
+		mockNotificationRepo.On("MarkNotificationSent", ctx, mock.Anything).Return(nil)
+		mockNotifier.On("Notify", ctx, notifier.Message{
+			To:      "alice@mail.com",
+			Subject: "Due soon: Dune",
+			Body:    "Hi alice,\n\nDune is due on 8 March 2024. Please return or renew it by then.\n",
+		}).Return(nil)
+		mockNotifier.On("Notify", ctx, notifier.Message{
+			To:      "bob@mail.com",
+			Subject: "Overdue: Emma",
+			Body:    "Hi bob,\n\nEmma was due on 8 March 2024. Please return it as soon as possible; a fine is charged for every day it is late.\n",
+		}).Return(nil)
+		reminderUsecase := usecase.NewReminderUsecase(mockNotificationRepo, mockNotifier, reminderConfig)
+
+		sent, err := reminderUsecase.SendReminders(ctx)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, sent)
+		mockNotificationRepo.AssertCalled(t, "MarkNotificationSent", ctx, 10)
+		mockNotificationRepo.AssertCalled(t, "MarkNotificationSent", ctx, 11)
+	})
+
+	t.Run("should not notify a reminder claimed by another run", func(t *testing.T) {
+		ctx := context.Background()
+		mockNotificationRepo := new(mocks.NotificationRepo)
+		mockNotifier := new(mocks.Notifier)
+		mockNotificationRepo.On("ListPendingReminders", ctx, 2, 5, 100).Return([]entity.Reminder{dueSoon}, nil)
+		mockNotificationRepo.On("ClaimNotification", ctx, &dueSoon, 5).Return(false, nil)
+		reminderUsecase := usecase.NewReminderUsecase(mockNotificationRepo, mockNotifier, reminderConfig)
+
+		sent, err := reminderUsecase.SendReminders(ctx)
+
+		assert.Nil(t, err)
+		assert.Zero(t, sent)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+		mockNotificationRepo.AssertNotCalled(t, "MarkNotificationSent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should mark a failed delivery for a retry and keep sending the other reminders", func(t *testing.T) {
+		ctx := context.Background()
+		errNotify := errors.New("error")
+		mockNotificationRepo := new(mocks.NotificationRepo)
+		mockNotifier := new(mocks.Notifier)
+		mockNotificationRepo.On("ListPendingReminders", ctx, 2, 5, 100).Return([]entity.Reminder{dueSoon, overdue}, nil)
+		claimReminder(mockNotificationRepo, 1, 10, 1)
+		claimReminder(mockNotificationRepo, 2, 11, 1)
+		mockNotificationRepo.On("MarkNotificationFailed", ctx, 10, 15*time.Minute, "error").Return(nil)
+		mockNotificationRepo.On("MarkNotificationSent", ctx, 11).Return(nil)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(message notifier.Message) bool { return message.To == "alice@mail.com" })).Return(errNotify)
+		mockNotifier.On("Notify", ctx, mock.MatchedBy(func(message notifier.Message) bool { return message.To == "bob@mail.com" })).Return(nil)
+		reminderUsecase := usecase.NewReminderUsecase(mockNotificationRepo, mockNotifier, reminderConfig)
+
+		sent, err := reminderUsecase.SendReminders(ctx)
+
+		assert.ErrorIs(t, err, errNotify)
+		assert.Equal(t, 1, sent)
+		mockNotificationRepo.AssertCalled(t, "MarkNotificationFailed", ctx, 10, 15*time.Minute, "error")
+		mockNotificationRepo.AssertNotCalled(t, "MarkNotificationSent", ctx, 10)
+	})
+
+	t.Run("should double the retry delay with every failed attempt", func(t *testing.T) {
+		ctx := context.Background()
+		errNotify := errors.New("error")
+		mockNotificationRepo := new(mocks.NotificationRepo)
+		mockNotifier := new(mocks.Notifier)
+		mockNotificationRepo.On("ListPendingReminders", ctx, 2, 5, 100).Return([]entity.Reminder{dueSoon}, nil)
+		claimReminder(mockNotificationRepo, 1, 10, 3)
+		mockNotificationRepo.On("MarkNotificationFailed", ctx, 10, time.Hour, "error").Return(nil)
+		mockNotifier.On("Notify", ctx, mock.Anything).Return(errNotify)
+		reminderUsecase := usecase.NewReminderUsecase(mockNotificationRepo, mockNotifier, reminderConfig)
+
+		sent, err := reminderUsecase.SendReminders(ctx)
+
+		assert.ErrorIs(t, err, errNotify)
+		assert.Zero(t, sent)
+		mockNotificationRepo.AssertCalled(t, "MarkNotificationFailed", ctx, 10, time.Hour, "error")
+	})
+
+	t.Run("should return error when listing the pending reminders encounters error", func(t *testing.T) {
+		ctx := context.Background()
+		errList := errors.New("error")
+		mockNotificationRepo := new(mocks.NotificationRepo)
+		mockNotifier := new(mocks.Notifier)
+		mockNotificationRepo.On("ListPendingReminders", ctx, 2, 5, 100).Return(nil, errList)
+		reminderUsecase := usecase.NewReminderUsecase(mockNotificationRepo, mockNotifier, reminderConfig)
+
+		sent, err := reminderUsecase.SendReminders(ctx)
+
+		assert.ErrorIs(t, err, errList)
+		assert.Zero(t, sent)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+}
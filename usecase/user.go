@@ -3,31 +3,87 @@ package usecase
 import (
 	"archive_lib/apperror"
 	"archive_lib/dto"
+	"archive_lib/entity"
 	"archive_lib/repo"
 	"archive_lib/util"
+	"archive_lib/util/tracing"
 	"context"
 	"strconv"
+	"time"
 )
 
+type TokenConfig struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
 type UserUsecase interface {
 	Login(ctx context.Context, authRequest *dto.AuthRequest) (*dto.AuthResponse, error)
+	Register(ctx context.Context, registerRequest *dto.RegisterRequest) (*dto.UserResponse, error)
+	Refresh(ctx context.Context, refreshRequest *dto.RefreshRequest) (*dto.AuthResponse, error)
+	Logout(ctx context.Context, logoutRequest *dto.LogoutRequest) error
 }
 
 type userUsecaseImpl struct {
-	userRepo repo.UserRepo
-	bcrypt   util.Bcrypt
-	jwt      util.JWT
+	userRepo    repo.UserRepo
+	tokenRepo   repo.TokenRepo
+	txRepo      repo.TransactionRepo
+	bcrypt      util.Bcrypt
+	jwt         util.JWT
+	tokenConfig TokenConfig
 }
 
-func NewUserUsecase(br repo.UserRepo, bcrypt util.Bcrypt, jwt util.JWT) userUsecaseImpl {
+func NewUserUsecase(br repo.UserRepo, tokenRepo repo.TokenRepo, txRepo repo.TransactionRepo, bcrypt util.Bcrypt, jwt util.JWT, tokenConfig TokenConfig) userUsecaseImpl {
 	return userUsecaseImpl{
-		userRepo: br,
-		bcrypt:   bcrypt,
-		jwt:      jwt,
+		userRepo:    br,
+		tokenRepo:   tokenRepo,
+		txRepo:      txRepo,
+		bcrypt:      bcrypt,
+		jwt:         jwt,
+		tokenConfig: tokenConfig,
 	}
 }
 
+// issueTokens signs an access token and stores a new refresh token in the given family.
+// An empty familyId starts a new family, identified by the hash of its first token.
+func (uc userUsecaseImpl) issueTokens(ctx context.Context, user *entity.User, familyId string) (*dto.AuthResponse, error) {
+	userId := strconv.Itoa(user.Id)
+	accessToken, err := uc.jwt.GenerateJWT(userId, user.Role)
+	if err != nil {
+		return nil, apperror.ErrLoginFailed{}
+	}
+
+	refreshToken, err := uc.jwt.GenerateRefreshToken()
+	if err != nil {
+		return nil, apperror.ErrLoginFailed{}
+	}
+
+	tokenHash := util.HashToken(refreshToken)
+	if familyId == "" {
+		familyId = tokenHash
+	}
+
+	err = uc.tokenRepo.AddRefreshToken(ctx, &entity.RefreshToken{
+		UserId:    user.Id,
+		FamilyId:  familyId,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(uc.tokenConfig.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(uc.tokenConfig.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
 func (uc userUsecaseImpl) Login(ctx context.Context, authRequest *dto.AuthRequest) (*dto.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.Login")
+	defer span.End()
+
 	email := authRequest.Email
 
 	found, err := uc.userRepo.IsEmailExisted(ctx, email)
@@ -49,11 +105,152 @@ func (uc userUsecaseImpl) Login(ctx context.Context, authRequest *dto.AuthReques
 		return nil, apperror.ErrWrongPassword{}
 	}
 
-	userId := strconv.Itoa(user.Id)
-	token, err := uc.jwt.GenerateJWT(userId)
+	return uc.issueTokens(ctx, user, "")
+}
+
+func (uc userUsecaseImpl) Register(ctx context.Context, registerRequest *dto.RegisterRequest) (*dto.UserResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.Register")
+	defer span.End()
+
+	duplicate, err := uc.userRepo.IsEmailExisted(ctx, registerRequest.Email)
 	if err != nil {
-		return nil, apperror.ErrLoginFailed{}
+		return nil, err
+	}
+	if duplicate {
+		return nil, apperror.ErrDuplicateEmail{}
+	}
+
+	hashedPassword, err := uc.bcrypt.GenerateFromPassword([]byte(registerRequest.Password))
+	if err != nil {
+		return nil, err
 	}
 
-	return &dto.AuthResponse{AccessToken: token}, nil
+	user, err := uc.userRepo.AddUser(ctx, &entity.User{
+		Username: registerRequest.Username,
+		Email:    registerRequest.Email,
+		Password: string(hashedPassword),
+		Role:     entity.RoleMember,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.UserResponse{
+		Id:       user.Id,
+		Username: user.Username,
+		Email:    user.Email,
+	}, nil
+}
+
+func (uc userUsecaseImpl) Refresh(ctx context.Context, refreshRequest *dto.RefreshRequest) (*dto.AuthResponse, error) {
+	ctx, span := tracing.Start(ctx, "UserUsecase.Refresh")
+	defer span.End()
+
+	var authResponse *dto.AuthResponse
+	var reused bool
+	tokenHash := util.HashToken(refreshRequest.RefreshToken)
+
+	err := uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		found, err := uc.tokenRepo.IsRefreshTokenExisted(txCtx, tokenHash)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrInvalidToken{}
+		}
+
+		stored, err := uc.tokenRepo.GetRefreshTokenByHash(txCtx, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		if stored.Revoked {
+			return apperror.ErrInvalidToken{}
+		}
+
+		// a rotated token presented again means it leaked, so the whole family is revoked;
+		// the revocation must be committed, hence no error is returned from the transaction
+		if stored.Rotated {
+			reused = true
+			return uc.tokenRepo.RevokeTokenFamily(txCtx, stored.FamilyId)
+		}
+
+		if time.Now().After(stored.ExpiresAt) {
+			return apperror.ErrInvalidToken{}
+		}
+
+		found, err = uc.userRepo.IsUserExisted(txCtx, stored.UserId)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrInvalidToken{}
+		}
+
+		user, err := uc.userRepo.GetUserById(txCtx, stored.UserId)
+		if err != nil {
+			return err
+		}
+
+		err = uc.tokenRepo.RotateRefreshToken(txCtx, stored.Id)
+		if err != nil {
+			return err
+		}
+
+		authResponse, err = uc.issueTokens(txCtx, user, stored.FamilyId)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return nil, apperror.ErrRefreshTokenReused{}
+	}
+
+	return authResponse, nil
+}
+
+func (uc userUsecaseImpl) Logout(ctx context.Context, logoutRequest *dto.LogoutRequest) error {
+	ctx, span := tracing.Start(ctx, "UserUsecase.Logout")
+	defer span.End()
+
+	// the access token is revoked on its own, so that it stays revoked even when the refresh token is invalid
+	err := uc.tokenRepo.RevokeAccessToken(ctx, logoutRequest.TokenId, logoutRequest.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	if !logoutRequest.All && logoutRequest.RefreshToken == "" {
+		return nil
+	}
+
+	return uc.txRepo.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if logoutRequest.All {
+			return uc.tokenRepo.RevokeUserRefreshTokens(txCtx, logoutRequest.UserId)
+		}
+
+		tokenHash := util.HashToken(logoutRequest.RefreshToken)
+		found, err := uc.tokenRepo.IsRefreshTokenExisted(txCtx, tokenHash)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return apperror.ErrInvalidToken{}
+		}
+
+		stored, err := uc.tokenRepo.GetRefreshTokenByHash(txCtx, tokenHash)
+		if err != nil {
+			return err
+		}
+		if stored.UserId != logoutRequest.UserId {
+			return apperror.ErrInvalidToken{}
+		}
+
+		return uc.tokenRepo.RevokeTokenFamily(txCtx, stored.FamilyId)
+	})
 }
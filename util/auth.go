@@ -1,7 +1,10 @@
 package util
 
 import (
-	"os"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -10,47 +13,98 @@ import (
 
 type Bcrypt interface {
 	CompareHashAndPassword(hashedPassword []byte, password []byte) error
+	GenerateFromPassword(password []byte) ([]byte, error)
 }
 
 type JWT interface {
-	GenerateJWT(userId string) (string, error)
+	GenerateJWT(userId string, role string) (string, error)
+	GenerateRefreshToken() (string, error)
+}
+
+type AccessClaims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// JWTConfig holds the signing secret, the issuer, and the lifetime of access tokens.
+type JWTConfig struct {
+	Secret         string
+	Issuer         string
+	AccessTokenTTL time.Duration
 }
 
 type bcryptImpl struct{}
 
-type jwtImpl struct{}
+type jwtImpl struct {
+	config JWTConfig
+}
 
 func NewBcrypt() bcryptImpl {
 	return bcryptImpl{}
 }
 
-func NewJWT() jwtImpl {
-	return jwtImpl{}
+func NewJWT(config JWTConfig) jwtImpl {
+	return jwtImpl{
+		config: config,
+	}
 }
 
 func (b bcryptImpl) CompareHashAndPassword(hashedPassword []byte, password []byte) error {
 	return bcrypt.CompareHashAndPassword(hashedPassword, password)
 }
 
-func (j jwtImpl) GenerateJWT(userId string) (string, error) {
+func (b bcryptImpl) GenerateFromPassword(password []byte) ([]byte, error) {
+	return bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
+}
+
+func (j jwtImpl) GenerateJWT(userId string, role string) (string, error) {
+	jti, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
 	now := time.Now()
 	registeredClaims := jwt.RegisteredClaims{
-		Issuer: "archive_lib",
+		ID:     jti,
+		Issuer: j.config.Issuer,
 		IssuedAt: &jwt.NumericDate{
 			Time: now,
 		},
 		Subject: userId,
 		ExpiresAt: &jwt.NumericDate{
-			Time: now.Add(24 * time.Hour),
+			Time: now.Add(j.config.AccessTokenTTL),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, registeredClaims)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, AccessClaims{
+		Role:             role,
+		RegisteredClaims: registeredClaims,
+	})
 
-	signedToken, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	signedToken, err := token.SignedString([]byte(j.config.Secret))
 	if err != nil {
 		return "", err
 	}
 
 	return signedToken, nil
 }
+
+func (j jwtImpl) GenerateRefreshToken() (string, error) {
+	return generateOpaqueToken()
+}
+
+// HashToken digests an opaque token so that only its hash is persisted.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
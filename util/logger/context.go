@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FromContext returns Log with the trace and span ids of the span carried by ctx, if any,
+// so log lines can be matched with their trace.
+func FromContext(ctx context.Context) Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return Log
+	}
+
+	return Log.WithFields(map[string]any{
+		"trace_id": spanContext.TraceID().String(),
+		"span_id":  spanContext.SpanID().String(),
+	})
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "archive_lib"
+
+// Reasons a borrow attempt is rejected for, the values of the reason label of BorrowsRejected.
+const (
+	ReasonEmptyStock      = "empty_stock"
+	ReasonCopyUnavailable = "copy_unavailable"
+	ReasonDuplicateLoan   = "duplicate_loan"
+	ReasonOverdueLoans    = "overdue_loans"
+	ReasonLoanLimit       = "loan_limit"
+	ReasonUnpaidFines     = "unpaid_fines"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests and HTTPRequestDuration are labelled by route template rather than raw path,
+	// so the number of series stays bounded.
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route, and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, by method, route, and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TransactionRollbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_rollbacks_total",
+		Help:      "Database transactions rolled back.",
+	})
+
+	BooksBorrowed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "books_borrowed_total",
+		Help:      "Books lent to members.",
+	})
+
+	BooksReturned = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "books_returned_total",
+		Help:      "Books returned by members.",
+	})
+
+	BorrowsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "borrows_rejected_total",
+		Help:      "Borrow attempts rejected, by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		TransactionRollbacks,
+		BooksBorrowed,
+		BooksReturned,
+		BorrowsRejected,
+	)
+}
+
+// RegisterDB exports the connection pool stats of db.
+func RegisterDB(db *sql.DB) {
+	registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogNotifierImpl writes every message as a JSON line instead of delivering it, for local testing.
+type LogNotifierImpl struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewLogNotifier(out io.Writer) *LogNotifierImpl {
+	return &LogNotifierImpl{
+		out: out,
+	}
+}
+
+func (n *LogNotifierImpl) Notify(ctx context.Context, message Message) error {
+	line, err := json.Marshal(map[string]any{
+		"time":    time.Now().Format(time.RFC3339),
+		"to":      message.To,
+		"subject": message.Subject,
+		"body":    message.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err = n.out.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// sendTimeout bounds a delivery when the caller's context has no earlier deadline,
+// so a stalled server cannot hold the reminder job forever.
+const sendTimeout = 30 * time.Second
+
+type SMTPNotifierImpl struct {
+	host string
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPNotifier sends messages through the SMTP server at host:port, authenticating
+// with PLAIN auth when a username is given.
+func NewSMTPNotifier(host string, port string, username string, password string, from string) *SMTPNotifierImpl {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPNotifierImpl{
+		host: host,
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+		from: from,
+	}
+}
+
+func (n *SMTPNotifierImpl) Notify(ctx context.Context, message Message) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// every read and write fails once the context is done, which unblocks the SMTP exchange
+	deadline, _ := ctx.Deadline()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		conn.SetDeadline(time.Now())
+	}()
+
+	err = n.send(conn, message)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// send mirrors smtp.SendMail on an already open connection.
+func (n *SMTPNotifierImpl) send(conn net.Conn, message Message) error {
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ok, _ := client.Extension("STARTTLS")
+	if ok {
+		err := client.StartTLS(&tls.Config{ServerName: n.host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.auth != nil {
+		ok, _ := client.Extension("AUTH")
+		if !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		err := client.Auth(n.auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(n.from)
+	if err != nil {
+		return err
+	}
+	err = client.Rcpt(message.To)
+	if err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(n.buildMail(message))
+	if err != nil {
+		return err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (n *SMTPNotifierImpl) buildMail(message Message) []byte {
+	var mailText strings.Builder
+	fmt.Fprintf(&mailText, "From: %s\r\n", (&mail.Address{Address: stripLineBreaks(n.from)}).String())
+	fmt.Fprintf(&mailText, "To: %s\r\n", (&mail.Address{Address: stripLineBreaks(message.To)}).String())
+	fmt.Fprintf(&mailText, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", stripLineBreaks(message.Subject)))
+	mailText.WriteString("MIME-Version: 1.0\r\n")
+	mailText.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	mailText.WriteString("\r\n")
+	mailText.WriteString(message.Body)
+
+	return []byte(mailText.String())
+}
+
+// stripLineBreaks drops CR and LF from a header value, as a book title or address holding
+// them could otherwise inject headers of its own.
+func stripLineBreaks(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, value)
+}
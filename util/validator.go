@@ -35,10 +35,18 @@ func ExtractValidationError(fe validator.FieldError) string {
 		return fmt.Sprintf("Should be greater than %s", fe.Param())
 	case "gt":
 		return fmt.Sprintf("Should be greater than %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("Should be less than %s", fe.Param())
+	case "min":
+		return fmt.Sprintf("Should be at least %s characters", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("Should be one of: %s", fe.Param())
 	case "max":
 		return fmt.Sprintf("Should be less than %s characters", fe.Param())
 	case "email":
 		return "Incorrect email format"
+	case "required_without":
+		return fmt.Sprintf("Required when %s is empty", strings.ToLower(fe.Param()))
 	default:
 		return "Mismatch data type or malformed request"
 	}
@@ -56,6 +64,38 @@ func ExtractUnmarshalError(je *json.UnmarshalTypeError) string {
 		return "Should be a number"
 	case "cover":
 		return "Should be a string"
+	case "name":
+		return "Should be a string"
+	case "username":
+		return "Should be a string"
+	case "email":
+		return "Should be a string"
+	case "password":
+		return "Should be a string"
+	case "amount":
+		return "Should be a number"
+	case "note":
+		return "Should be a string"
+	case "refresh_token":
+		return "Should be a string"
+	case "all":
+		return "Should be a boolean"
+	case "book_ids":
+		return "Should be a list of numbers"
+	case "ids":
+		return "Should be a list of numbers"
+	case "mode":
+		return "Should be a string"
+	case "barcode":
+		return "Should be a string"
+	case "status":
+		return "Should be a string"
+	case "replacement_charge":
+		return "Should be a number"
+	case "condition":
+		return "Should be a string"
+	case "location":
+		return "Should be a string"
 	default:
 		return "Mismatch data type or malformed request"
 	}